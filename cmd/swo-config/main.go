@@ -0,0 +1,88 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command swo-config loads the SolarWinds Observability APM configuration the
+// same way as the library, from the config file and the environment
+// variables, and prints the effective value and source of every setting. It
+// reports the unknown keys of the config file and the invalid values, and
+// exits with a non-zero status if there is any, so that it can gate the
+// deployments:
+//
+//	SW_APM_CONFIG_FILE=./solarwinds-apm-goagent.yaml swo-config
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/solarwinds/apm-go/swo"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("swo-config", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	quiet := flags.Bool("q", false, "only print the errors")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(stderr, "Usage: swo-config [-q]")
+		_, _ = fmt.Fprintln(stderr, "Print the effective configuration and report its errors.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	// The problems are reported below rather than logged
+	swo.SetLogOutput(io.Discard)
+	entries, err := swo.ValidateConfig()
+
+	if !*quiet {
+		w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "KEY\tVALUE\tSOURCE")
+		for _, e := range entries {
+			source := string(e.Source)
+			if e.Origin != "" {
+				source += " (" + e.Origin + ")"
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", e.Key, e.Value, source)
+		}
+		_ = w.Flush()
+	}
+
+	if err == nil {
+		return 0
+	}
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	for _, e := range errs {
+		_, _ = fmt.Fprintf(stderr, "error: %s\n", e)
+	}
+	_, _ = fmt.Fprintf(stderr, "%d %s found\n", len(errs), plural(len(errs), "error"))
+	return 1
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
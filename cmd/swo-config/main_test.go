@@ -0,0 +1,52 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "solarwinds-apm-goagent.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("DebugLevel: info\n"), 0644))
+	t.Setenv("SW_APM_CONFIG_FILE", path)
+	t.Setenv("SW_APM_SERVICE_KEY", "ae38315f6116585d64d82ec2455aa3ec61e02fee25d286f74ace9e4fea189217:go")
+
+	var stdout, stderr bytes.Buffer
+	require.Equal(t, 0, run(nil, &stdout, &stderr))
+	assert.Contains(t, stdout.String(), "KEY")
+	assert.Regexp(t, `DebugLevel\s+info\s+file \(`+path+`\)`, stdout.String())
+	assert.Regexp(t, `ServiceKey\s+ae38\*+9217:go\s+env \(SW_APM_SERVICE_KEY\)`, stdout.String())
+	assert.Regexp(t, `Collector\s+\S+\s+default\n`, stdout.String())
+	assert.Empty(t, stderr.String())
+
+	require.NoError(t, os.WriteFile(path, []byte("DebugLevel: verbose\nUnknown: 1\n"), 0644))
+	stdout.Reset()
+	require.Equal(t, 1, run([]string{"-q"}, &stdout, &stderr))
+	assert.Empty(t, stdout.String())
+	assert.Equal(t, "error: "+path+": Unknown: unknown key\n"+
+		"error: DebugLevel: invalid value verbose from file "+path+", the agent uses warn\n"+
+		"2 errors found\n", stderr.String())
+
+	stderr.Reset()
+	require.Equal(t, 2, run([]string{"-unknown"}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "Usage: swo-config")
+}
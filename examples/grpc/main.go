@@ -16,8 +16,8 @@ package main
 
 import (
 	"context"
+	"github.com/solarwinds/apm-go/instrumentation/google.golang.org/grpc/swogrpc"
 	"github.com/solarwinds/apm-go/swo"
-	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 	"google.golang.org/grpc"
@@ -46,12 +46,16 @@ func main() {
 		log.Fatalf("failed to listen: %v", err)
 	}
 	grpcServer := grpc.NewServer(
-		// We provide `otelgrpc` interceptors to add instrumentation to each call
-		grpc.UnaryInterceptor(otelgrpc.UnaryServerInterceptor()),
-		// Even though we have no streaming calls in this service, adding this
-		// instrumentation adds zero overhead. It's a good idea to _always_ have
-		// both Unary and Stream interceptors for both server and client.
-		grpc.StreamInterceptor(otelgrpc.StreamServerInterceptor()),
+		// We provide `swogrpc` instrumentation for each call, which also
+		// honors the trigger trace request metadata
+		grpc.StatsHandler(swogrpc.NewServerHandler()),
+		// The interceptors name the transactions and return the `x-trace`
+		// response headers. Even though we have no streaming calls in this
+		// service, adding this instrumentation adds zero overhead. It's a good
+		// idea to _always_ have both Unary and Stream interceptors for both
+		// server and client.
+		grpc.UnaryInterceptor(swogrpc.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(swogrpc.StreamServerInterceptor()),
 	)
 	RegisterPingerServer(grpcServer, newPingServer())
 
@@ -60,9 +64,10 @@ func main() {
 		// Here we create an instrumented client
 		conn, err := grpc.Dial("localhost:9090",
 			grpc.WithTransportCredentials(insecure.NewCredentials()),
-			// otelgrpc instrumentation for the client
-			grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
-			grpc.WithStreamInterceptor(otelgrpc.StreamClientInterceptor()),
+			// swogrpc instrumentation for the client
+			grpc.WithStatsHandler(swogrpc.NewClientHandler()),
+			grpc.WithUnaryInterceptor(swogrpc.UnaryClientInterceptor()),
+			grpc.WithStreamInterceptor(swogrpc.StreamClientInterceptor()),
 		)
 		if err != nil {
 			panic(err.Error())
@@ -17,6 +17,7 @@ module github.com/solarwinds/apm-go
 go 1.25.0
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/coocood/freecache v1.2.7
 	github.com/google/uuid v1.6.0
 	github.com/stretchr/testify v1.11.1
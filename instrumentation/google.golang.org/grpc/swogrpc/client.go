@@ -0,0 +1,66 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swogrpc
+
+import (
+	"context"
+
+	"github.com/solarwinds/apm-go/internal/xtrace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type xtraceOptions struct {
+	options   string
+	signature string
+}
+
+type xtraceOptionsKey struct{}
+
+// ContextWithXTraceOptions returns a copy of ctx carrying the given
+// `x-trace-options` and, if not empty, `x-trace-options-signature` values. The
+// client interceptors send them as request metadata, e.g. to trigger a trace
+// in the called service.
+func ContextWithXTraceOptions(ctx context.Context, options, signature string) context.Context {
+	return context.WithValue(ctx, xtraceOptionsKey{}, xtraceOptions{options: options, signature: signature})
+}
+
+// UnaryClientInterceptor sends the options set by ContextWithXTraceOptions as
+// request metadata.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor is the streaming counterpart of
+// UnaryClientInterceptor.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+func outgoingContext(ctx context.Context) context.Context {
+	xto, ok := ctx.Value(xtraceOptionsKey{}).(xtraceOptions)
+	if !ok || xto.options == "" {
+		return ctx
+	}
+	kv := []string{xtrace.OptionsHeaderName, xto.options}
+	if xto.signature != "" {
+		kv = append(kv, xtrace.OptionsSigHeaderName, xto.signature)
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
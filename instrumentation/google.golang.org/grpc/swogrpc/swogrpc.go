@@ -0,0 +1,121 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package swogrpc provides SolarWinds APM instrumentation for gRPC servers and
+// clients on top of otelgrpc.
+package swogrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/solarwinds/apm-go/internal/entryspans"
+	"github.com/solarwinds/apm-go/internal/log"
+	"github.com/solarwinds/apm-go/internal/swotel"
+	"github.com/solarwinds/apm-go/internal/xtrace"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+)
+
+const (
+	XTraceHdr         = "x-trace"
+	XTraceOptsRespHdr = "x-trace-options-response"
+)
+
+// NewServerHandler returns an otelgrpc server stats.Handler which also reads
+// the `x-trace-options` and `x-trace-options-signature` request metadata, so
+// that trigger trace requests are honored by the sampler.
+func NewServerHandler(opts ...otelgrpc.Option) stats.Handler {
+	return &serverHandler{Handler: otelgrpc.NewServerHandler(opts...)}
+}
+
+type serverHandler struct {
+	stats.Handler
+}
+
+var _ stats.Handler = &serverHandler{}
+
+func (h *serverHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if opts := md.Get(xtrace.OptionsHeaderName); len(opts) > 0 {
+			ctx = context.WithValue(ctx, xtrace.OptionsKey, opts[0])
+		}
+		if sig := md.Get(xtrace.OptionsSigHeaderName); len(sig) > 0 {
+			ctx = context.WithValue(ctx, xtrace.SignatureKey, sig[0])
+		}
+	}
+	return h.Handler.TagRPC(ctx, info)
+}
+
+// NewClientHandler returns an otelgrpc client stats.Handler.
+func NewClientHandler(opts ...otelgrpc.Option) stats.Handler {
+	return otelgrpc.NewClientHandler(opts...)
+}
+
+// UnaryServerInterceptor returns the `x-trace` and `x-trace-options-response`
+// response headers and names the transaction after the full gRPC method. It
+// must be used together with NewServerHandler.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		instrumentServerCall(ctx, info.FullMethod, func(md metadata.MD) error {
+			return grpc.SetHeader(ctx, md)
+		})
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		instrumentServerCall(ss.Context(), info.FullMethod, ss.SetHeader)
+		return handler(srv, ss)
+	}
+}
+
+func instrumentServerCall(ctx context.Context, fullMethod string, setHeader func(metadata.MD) error) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+
+	// Only name the transaction if this call's span is the entry span, so that
+	// in-process calls do not rename the transaction of their caller.
+	if sid, ok := entryspans.Current(sc.TraceID()); ok && sid == sc.SpanID() {
+		if err := entryspans.SetTransactionName(sc.TraceID(), fullMethod); err != nil {
+			log.Debugf("Could not set transaction name: %s", err)
+		}
+	}
+
+	flags := "00"
+	if sc.IsSampled() {
+		flags = "01"
+	}
+	md := metadata.Pairs(XTraceHdr, fmt.Sprintf("00-%s-%s-%s", sc.TraceID().String(), sc.SpanID().String(), flags))
+
+	resp, err := swotel.GetInternalState(sc.TraceState(), swotel.XTraceOptResp)
+	if err != nil {
+		log.Debugf("Could not get xtrace opt resp header: %s", err)
+	}
+	if resp != "" {
+		md.Append(XTraceOptsRespHdr, resp)
+	}
+
+	if err := setHeader(md); err != nil {
+		log.Debugf("Could not set response header: %s", err)
+	}
+}
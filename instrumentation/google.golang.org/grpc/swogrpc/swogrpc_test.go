@@ -0,0 +1,154 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swogrpc
+
+import (
+	"context"
+	"net"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/solarwinds/apm-go/internal/entryspans"
+	"github.com/solarwinds/apm-go/internal/testutils"
+	"github.com/solarwinds/apm-go/swo"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var xtraceRegexp = regexp.MustCompile(`\A00-[[:xdigit:]]{32}-[[:xdigit:]]{16}-0[01]\z`)
+
+func setupTest(t *testing.T) func() {
+	require.NoError(t, os.Setenv("SW_APM_SERVICE_KEY", "ae38315f6116585d64d82ec2455aa3ec61e02fee25d286f74ace9e4fea189217:service-name"))
+	require.NoError(t, os.Setenv("SW_APM_DISABLED_RESOURCE_DETECTORS", "ec2,azurevm,uams,k8s"))
+
+	config.Load()
+
+	return func() {
+		if err := os.Unsetenv("SW_APM_SERVICE_KEY"); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Unsetenv("SW_APM_DISABLED_RESOURCE_DETECTORS"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func newHealthClient(t *testing.T) grpc_health_v1.HealthClient {
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer(
+		grpc.StatsHandler(NewServerHandler()),
+		grpc.UnaryInterceptor(UnaryServerInterceptor()),
+		grpc.StreamInterceptor(StreamServerInterceptor()),
+	)
+	grpc_health_v1.RegisterHealthServer(srv, health.NewServer())
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(NewClientHandler()),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(StreamClientInterceptor()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return grpc_health_v1.NewHealthClient(conn)
+}
+
+func TestUnaryNoXOptsResponse(t *testing.T) {
+	defer setupTest(t)()
+
+	cb, err := swo.Start()
+	require.NoError(t, err)
+	defer cb()
+
+	var md metadata.MD
+	_, err = newHealthClient(t).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{}, grpc.Header(&md))
+	require.NoError(t, err)
+	require.Len(t, md.Get(XTraceHdr), 1)
+	require.Regexp(t, xtraceRegexp, md.Get(XTraceHdr)[0])
+	require.Empty(t, md.Get(XTraceOptsRespHdr))
+}
+
+func TestUnaryWithXOptsResponse(t *testing.T) {
+	defer setupTest(t)()
+
+	cb, err := swo.Start()
+	require.NoError(t, err)
+	defer cb()
+
+	var md metadata.MD
+	ctx := ContextWithXTraceOptions(context.Background(), "trigger-trace", "")
+	_, err = newHealthClient(t).Check(ctx, &grpc_health_v1.HealthCheckRequest{}, grpc.Header(&md))
+	require.NoError(t, err)
+	require.Len(t, md.Get(XTraceHdr), 1)
+	require.Regexp(t, xtraceRegexp, md.Get(XTraceHdr)[0])
+	// No settings are available as the agent cannot reach the collector in tests
+	require.Equal(t, []string{"trigger-trace=settings-not-available"}, md.Get(XTraceOptsRespHdr))
+}
+
+func TestStreamWithXOptsResponse(t *testing.T) {
+	defer setupTest(t)()
+
+	cb, err := swo.Start()
+	require.NoError(t, err)
+	defer cb()
+
+	ctx, cancel := context.WithCancel(ContextWithXTraceOptions(context.Background(), "trigger-trace", ""))
+	defer cancel()
+	stream, err := newHealthClient(t).Watch(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	md, err := stream.Header()
+	require.NoError(t, err)
+	require.Len(t, md.Get(XTraceHdr), 1)
+	require.Regexp(t, xtraceRegexp, md.Get(XTraceHdr)[0])
+	require.Equal(t, []string{"trigger-trace=settings-not-available"}, md.Get(XTraceOptsRespHdr))
+}
+
+func TestInstrumentServerCallSetsTransactionName(t *testing.T) {
+	tr, teardown := testutils.TracerSetup()
+	defer teardown()
+
+	ctx, entry := tr.Start(context.Background(), "entry")
+	require.NoError(t, entryspans.Push(entry.(sdktrace.ReadWriteSpan)))
+	defer func() { require.NoError(t, entryspans.Delete(entry.(sdktrace.ReadOnlySpan))) }()
+
+	var md metadata.MD
+	instrumentServerCall(ctx, "/grpc.health.v1.Health/Check", func(m metadata.MD) error {
+		md = m
+		return nil
+	})
+	require.Equal(t, "/grpc.health.v1.Health/Check", entryspans.GetTransactionName(entry.SpanContext().TraceID()))
+	require.Regexp(t, xtraceRegexp, md.Get(XTraceHdr)[0])
+
+	// A span which is not the entry span must not rename the transaction
+	childCtx, child := tr.Start(ctx, "child")
+	defer child.End()
+	instrumentServerCall(childCtx, "/other.Service/Method", func(metadata.MD) error { return nil })
+	require.Equal(t, "/grpc.health.v1.Health/Check", entryspans.GetTransactionName(entry.SpanContext().TraceID()))
+}
@@ -34,6 +34,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/solarwinds/apm-go/internal/log"
 
@@ -45,6 +46,12 @@ const (
 	MaxSampleRate = 1000000
 	// MinSampleRate is the minimum sample rate we can have
 	MinSampleRate = 0
+	// MaxPrecision is the maximum histogram precision, the finest exponential
+	// histogram scale being 20
+	MaxPrecision = 6
+	// MaxSettingsUpdateJitter is the maximum percentage of the settings update
+	// interval by which an update is randomly advanced or delayed
+	MaxSettingsUpdateJitter = 50
 	// max config file size = 1MB
 	maxConfigFileSize = 1024 * 1024
 	// the default collector url
@@ -65,6 +72,7 @@ const (
 	envSolarWindsAPMHistogramPrecision     = "SW_APM_HISTOGRAM_PRECISION"
 	envSolarWindsAPMEnabled                = "SW_APM_ENABLED"
 	envSolarWindsAPMConfigFile             = "SW_APM_CONFIG_FILE"
+	envSolarWindsAPMConfigOverrideFile     = "SW_APM_CONFIG_OVERRIDE_FILE"
 	envSolarWindsAPMServerlessServiceName  = "SW_APM_SERVICE_NAME"
 	envSolarWindsAPMTokenBucketCap         = "SW_APM_TOKEN_BUCKET_CAPACITY"
 	envSolarWindsAPMTokenBucketRate        = "SW_APM_TOKEN_BUCKET_RATE"
@@ -80,6 +88,8 @@ var (
 	ErrUnsupportedFormat = errors.New("unsupported format")
 	ErrFileTooLarge      = errors.New("file size exceeds limit")
 	ErrInvalidServiceKey = errors.New(fullTextInvalidServiceKey)
+	// ErrInvalidReloadedConfig is returned when the reloaded config is rejected
+	ErrInvalidReloadedConfig = errors.New("invalid reloaded config")
 )
 
 // Config is the struct to define the agent configuration. The configuration
@@ -94,6 +104,20 @@ type Config struct {
 	// SettingsURL defines the HTTP URL for fetching sampling settings
 	SettingsURL string
 
+	// The file where the last sampling settings fetched are saved and read
+	// from on startup. The settings are not cached if it's empty.
+	SettingsCacheFile string `yaml:"SettingsCacheFile,omitempty" env:"SW_APM_SETTINGS_CACHE_FILE"`
+
+	// The maximum delay in seconds of the first fetch of the sampling
+	// settings, picked randomly so that the instances restarted together
+	// don't hit the settings API at once. 0 disables it.
+	SettingsStartupJitter int `yaml:"SettingsStartupJitter,omitempty" env:"SW_APM_SETTINGS_STARTUP_JITTER" default:"1"`
+
+	// The percentage of the settings update interval by which each update
+	// is randomly advanced or delayed so that the instances don't poll in
+	// lockstep. It's between 0 (disabled) and 50.
+	SettingsUpdateJitter int `yaml:"SettingsUpdateJitter,omitempty" env:"SW_APM_SETTINGS_UPDATE_JITTER" default:"10"`
+
 	// ServiceKey defines the service key and service name
 	ServiceKey string `yaml:"ServiceKey,omitempty" env:"SW_APM_SERVICE_KEY"`
 
@@ -105,12 +129,25 @@ type Config struct {
 	// Whether the domain should be prepended to the transaction name.
 	PrependDomain bool `yaml:"PrependDomain,omitempty" env:"SW_APM_PREPEND_DOMAIN"`
 
+	// The maximum number of distinct transaction names in the response time
+	// metrics per export. The others are recorded as the `other` transaction.
+	TransactionMetricsLimit int `yaml:"TransactionMetricsLimit,omitempty" env:"SW_APM_TRANSACTION_METRICS_LIMIT" default:"200"`
+
 	// The alias of the hostname
 	HostAlias string `yaml:"HostAlias,omitempty" env:"SW_APM_HOSTNAME_ALIAS"`
 
-	// The precision of the histogram
+	// The precision of the histogram, i.e. the number of significant decimal
+	// digits of the exponential histogram buckets, from 0 to MaxPrecision
 	Precision int `yaml:"Precision,omitempty" env:"SW_APM_HISTOGRAM_PRECISION" default:"2"`
 
+	// The unit of the response time histogram, either `ms` or `us`
+	HistogramUnit string `yaml:"HistogramUnit,omitempty" env:"SW_APM_HISTOGRAM_UNIT" default:"ms"`
+
+	// The aggregation of the response time histogram, either `explicit` or
+	// `exponential`. The default aggregation of the exporter, exponential
+	// unless set otherwise, is used if it's empty.
+	HistogramAggregation string `yaml:"HistogramAggregation,omitempty" env:"SW_APM_HISTOGRAM_AGGREGATION"`
+
 	// The SQL sanitization level
 	SQLSanitize int `yaml:"SQLSanitize,omitempty" env:"SW_APM_SQL_SANITIZE" default:"0"`
 
@@ -120,6 +157,18 @@ type Config struct {
 	// The transaction filtering config
 	TransactionSettings []TransactionFilter `yaml:"TransactionSettings,omitempty"`
 
+	// The rules deriving the transaction names from the URL paths
+	TransactionNaming TransactionNamingConfig `yaml:"TransactionNaming,omitempty"`
+
+	// The span attributes redacted before export
+	Redaction RedactionConfig `yaml:"Redaction,omitempty"`
+
+	// The filters dropping the noisy spans before export
+	SpanFilters []SpanFilter `yaml:"SpanFilters,omitempty"`
+
+	// The buffering of the traces which are not sampled
+	TailSampling TailSamplingConfig `yaml:"TailSampling,omitempty"`
+
 	Enabled bool `yaml:"Enabled,omitempty" env:"SW_APM_ENABLED" default:"true"`
 
 	// EC2 metadata retrieval timeout in milliseconds
@@ -128,6 +177,18 @@ type Config struct {
 	// The default log level. It should follow the level defined in log.DefaultLevel
 	DebugLevel string `yaml:"DebugLevel,omitempty" env:"SW_APM_DEBUG_LEVEL" default:"warn"`
 
+	// The output format of the logs, either `text` or `json`
+	LogFormat string `yaml:"LogFormat,omitempty" env:"SW_APM_LOG_FORMAT" default:"text"`
+
+	// The interval in seconds during which a repeated log message is printed
+	// at most once. The repetitions are counted and reported by the next
+	// message printed. It's disabled if 0.
+	LogRateLimitInterval int `yaml:"LogRateLimitInterval,omitempty" env:"SW_APM_LOG_RATE_LIMIT_INTERVAL" default:"600"`
+
+	// The interval in seconds to check the config file for changes, which are
+	// applied without a restart. It's disabled if 0.
+	ConfigReloadInterval int `yaml:"ConfigReloadInterval,omitempty" env:"SW_APM_CONFIG_RELOAD_INTERVAL" default:"30"`
+
 	// The flag for trigger trace. It's enabled by default.
 	TriggerTrace bool `yaml:"TriggerTrace" env:"SW_APM_TRIGGER_TRACE" default:"true"`
 
@@ -164,6 +225,20 @@ type FilterType string
 const (
 	// URL based filter
 	URL FilterType = "url"
+	// Transaction name based sampling settings
+	Transaction FilterType = "transaction"
+)
+
+// The units of the response time histogram
+const (
+	HistogramUnitMilliseconds = "ms"
+	HistogramUnitMicroseconds = "us"
+)
+
+// The aggregations of the response time histogram
+const (
+	HistogramAggregationExplicit    = "explicit"
+	HistogramAggregationExponential = "exponential"
 )
 
 // TracingMode defines the tracing mode which is either `enabled` or `disabled`
@@ -179,11 +254,30 @@ const (
 )
 
 // TransactionFilter defines the transaction filtering based on a filter type.
+//
+// A `url` filter matches the request URL by either RegEx or Extensions and
+// sets its tracing mode. A `transaction` filter matches the transaction Name
+// exactly and may set the tracing mode, the sample rate and a dedicated token
+// bucket for this transaction.
 type TransactionFilter struct {
 	Type       FilterType  `yaml:"Type"`
 	RegEx      string      `yaml:"RegEx,omitempty"`
 	Extensions []string    `yaml:"Extensions,omitempty"`
 	Tracing    TracingMode `yaml:"Tracing"`
+
+	// The transaction name, only used by the `transaction` filters
+	Name string `yaml:"Name,omitempty"`
+	// The sample rate of the transaction, the service's one is used if nil
+	SampleRate *int `yaml:"SampleRate,omitempty"`
+	// The capacity and rate of the transaction's own token bucket. The
+	// transaction shares the service's token bucket if neither is set.
+	TokenBucketCap  *float64 `yaml:"TokenBucketCap,omitempty"`
+	TokenBucketRate *float64 `yaml:"TokenBucketRate,omitempty"`
+}
+
+// HasTokenBucket returns if the filter defines a dedicated token bucket
+func (f TransactionFilter) HasTokenBucket() bool {
+	return f.TokenBucketCap != nil || f.TokenBucketRate != nil
 }
 
 // TransactionFilter unmarshal errors
@@ -191,35 +285,75 @@ var (
 	ErrTFInvalidType     = errors.New("invalid Type")
 	ErrTFInvalidTracing  = errors.New("invalid Tracing")
 	ErrTFInvalidRegExExt = errors.New("must set either RegEx or Extensions, but not both")
+	ErrTFInvalidName     = errors.New("must set Name but neither RegEx nor Extensions")
+	ErrTFInvalidRate     = errors.New("invalid SampleRate")
+	ErrTFInvalidBucket   = errors.New("invalid TokenBucketCap or TokenBucketRate")
 )
 
 // UnmarshalYAML is the customized unmarshal method for TransactionFilter
 func (f *TransactionFilter) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	initStruct(f)
 	var aux = struct {
-		Type       FilterType  `yaml:"Type"`
-		RegEx      string      `yaml:"RegEx,omitempty"`
-		Extensions []string    `yaml:"Extensions,omitempty"`
-		Tracing    TracingMode `yaml:"Tracing"`
+		Type            FilterType  `yaml:"Type"`
+		RegEx           string      `yaml:"RegEx,omitempty"`
+		Extensions      []string    `yaml:"Extensions,omitempty"`
+		Tracing         TracingMode `yaml:"Tracing"`
+		Name            string      `yaml:"Name,omitempty"`
+		SampleRate      *int        `yaml:"SampleRate,omitempty"`
+		TokenBucketCap  *float64    `yaml:"TokenBucketCap,omitempty"`
+		TokenBucketRate *float64    `yaml:"TokenBucketRate,omitempty"`
 	}{}
 
 	if err := unmarshal(&aux); err != nil {
 		return fmt.Errorf("failed to unmarshal TransactionFilter: %w", err)
 	}
-	if aux.Type != URL {
-		return ErrTFInvalidType
+	filter := TransactionFilter{
+		Type:            aux.Type,
+		RegEx:           aux.RegEx,
+		Extensions:      aux.Extensions,
+		Tracing:         aux.Tracing,
+		Name:            aux.Name,
+		SampleRate:      aux.SampleRate,
+		TokenBucketCap:  aux.TokenBucketCap,
+		TokenBucketRate: aux.TokenBucketRate,
 	}
-	if aux.Tracing != EnabledTracingMode && aux.Tracing != DisabledTracingMode {
-		return ErrTFInvalidTracing
-	}
-	if (aux.RegEx == "") == (aux.Extensions == nil) {
-		return ErrTFInvalidRegExExt
+	if err := filter.validate(); err != nil {
+		return err
 	}
 
-	f.Type = aux.Type
-	f.RegEx = aux.RegEx
-	f.Extensions = aux.Extensions
-	f.Tracing = aux.Tracing
+	*f = filter
+	return nil
+}
+
+// validate checks the filter type and tracing mode. A `url` filter must set
+// exactly one of RegEx and Extensions, while a `transaction` filter must set
+// the Name, an optional tracing mode and valid sampling values.
+func (f TransactionFilter) validate() error {
+	switch f.Type {
+	case URL:
+		if f.Tracing != EnabledTracingMode && f.Tracing != DisabledTracingMode {
+			return ErrTFInvalidTracing
+		}
+		if (f.RegEx == "") == (f.Extensions == nil) {
+			return ErrTFInvalidRegExExt
+		}
+	case Transaction:
+		if f.Tracing != "" && f.Tracing != EnabledTracingMode && f.Tracing != DisabledTracingMode {
+			return ErrTFInvalidTracing
+		}
+		if strings.TrimSpace(f.Name) == "" || f.RegEx != "" || f.Extensions != nil {
+			return ErrTFInvalidName
+		}
+		if f.SampleRate != nil && !IsValidSampleRate(*f.SampleRate) {
+			return ErrTFInvalidRate
+		}
+		if (f.TokenBucketCap != nil && *f.TokenBucketCap < 0) ||
+			(f.TokenBucketRate != nil && *f.TokenBucketRate < 0) {
+			return ErrTFInvalidBucket
+		}
+	default:
+		return ErrTFInvalidType
+	}
 	return nil
 }
 
@@ -230,7 +364,11 @@ func (s *SamplingConfig) Configured() bool {
 
 // UnmarshalYAML is the customized unmarshal method for SamplingConfig
 func (s *SamplingConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
-	initStruct(s)
+	// An existing config, e.g. from the base config file, is merged with the
+	// keys set by the override file.
+	if *s == (SamplingConfig{}) {
+		initStruct(s)
+	}
 	var aux = struct {
 		TracingMode TracingMode `yaml:"TracingMode"`
 		SampleRate  int         `yaml:"SampleRate"`
@@ -323,6 +461,68 @@ func WithServiceKey(key string) Option {
 	}
 }
 
+// WithSampleRate defines a Config option for the local sample rate.
+func WithSampleRate(rate int) Option {
+	return func(c *Config) {
+		c.Sampling.SetSampleRate(rate)
+	}
+}
+
+// WithTracingMode defines a Config option for the local tracing mode.
+func WithTracingMode(mode TracingMode) Option {
+	return func(c *Config) {
+		c.Sampling.SetTracingMode(mode)
+	}
+}
+
+// WithProxy defines a Config option for the HTTP/HTTPS proxy url.
+func WithProxy(proxy string) Option {
+	return func(c *Config) {
+		c.Proxy = proxy
+	}
+}
+
+// WithTransactionFilters defines a Config option for the transaction filtering
+// settings. It replaces the filters loaded from the config file.
+func WithTransactionFilters(filters []TransactionFilter) Option {
+	return func(c *Config) {
+		c.TransactionSettings = filters
+	}
+}
+
+// WithRedaction defines a Config option for the redacted span attributes.
+// The keys and values are added to those loaded from the config file.
+func WithRedaction(r RedactionConfig) Option {
+	return func(c *Config) {
+		c.Redaction.Keys = append(c.Redaction.Keys, r.Keys...)
+		c.Redaction.Values = append(c.Redaction.Values, r.Values...)
+	}
+}
+
+// WithSpanFilters defines a Config option for the span filters. It replaces
+// the filters loaded from the config file.
+func WithSpanFilters(filters []SpanFilter) Option {
+	return func(c *Config) {
+		c.SpanFilters = filters
+	}
+}
+
+// WithTailSampling defines a Config option for the buffering of the traces
+// which are not sampled.
+func WithTailSampling(tail TailSamplingConfig) Option {
+	return func(c *Config) {
+		c.TailSampling = tail
+	}
+}
+
+// WithTransactionNaming defines a Config option for the transaction naming
+// rules. It replaces the rules loaded from the config file.
+func WithTransactionNaming(naming TransactionNamingConfig) Option {
+	return func(c *Config) {
+		c.TransactionNaming = naming
+	}
+}
+
 // NewConfig initializes a Config object and override default values with options
 // provided as arguments. It may print errors if there are invalid values in the
 // configuration file or the environment variables.
@@ -368,6 +568,51 @@ func (c *Config) validate() error {
 		c.Ec2MetadataTimeout = t
 	}
 
+	if c.ConfigReloadInterval < 0 {
+		log.Warning(InvalidEnv("ConfigReloadInterval", strconv.Itoa(c.ConfigReloadInterval)))
+		c.ConfigReloadInterval = 0
+	}
+
+	if c.SettingsStartupJitter < 0 {
+		log.Warning(InvalidEnv("SettingsStartupJitter", strconv.Itoa(c.SettingsStartupJitter)))
+		j, _ := strconv.Atoi(getFieldDefaultValue(c, "SettingsStartupJitter"))
+		c.SettingsStartupJitter = j
+	}
+
+	if c.SettingsUpdateJitter < 0 || c.SettingsUpdateJitter > MaxSettingsUpdateJitter {
+		log.Warning(InvalidEnv("SettingsUpdateJitter", strconv.Itoa(c.SettingsUpdateJitter)))
+		j, _ := strconv.Atoi(getFieldDefaultValue(c, "SettingsUpdateJitter"))
+		c.SettingsUpdateJitter = j
+	}
+
+	if ok := IsValidPrecision(c.Precision); !ok {
+		log.Warning(InvalidEnv("Precision", strconv.Itoa(c.Precision)))
+		p, _ := strconv.Atoi(getFieldDefaultValue(c, "Precision"))
+		c.Precision = p
+	}
+
+	if c.TransactionMetricsLimit <= 0 {
+		log.Warning(InvalidEnv("TransactionMetricsLimit", strconv.Itoa(c.TransactionMetricsLimit)))
+		l, _ := strconv.Atoi(getFieldDefaultValue(c, "TransactionMetricsLimit"))
+		c.TransactionMetricsLimit = l
+	}
+
+	if ok := IsValidHistogramUnit(c.HistogramUnit); !ok {
+		log.Warning(InvalidEnv("HistogramUnit", c.HistogramUnit))
+		c.HistogramUnit = getFieldDefaultValue(c, "HistogramUnit")
+	}
+
+	if ok := IsValidHistogramAggregation(c.HistogramAggregation); !ok {
+		log.Warning(InvalidEnv("HistogramAggregation", c.HistogramAggregation))
+		c.HistogramAggregation = getFieldDefaultValue(c, "HistogramAggregation")
+	}
+
+	if ok := IsValidSQLSanitize(c.SQLSanitize); !ok {
+		log.Warning(InvalidEnv("SQLSanitize", strconv.Itoa(c.SQLSanitize)))
+		l, _ := strconv.Atoi(getFieldDefaultValue(c, "SQLSanitize"))
+		c.SQLSanitize = l
+	}
+
 	if c.TransactionName != "" && !HasLambdaEnv() {
 		log.Info(InvalidEnv("TransactionName", c.TransactionName))
 		c.TransactionName = getFieldDefaultValue(c, "TransactionName")
@@ -384,6 +629,21 @@ func (c *Config) validate() error {
 
 	c.Sampling.validate()
 
+	var filters []TransactionFilter
+	for _, filter := range c.TransactionSettings {
+		if err := filter.validate(); err != nil {
+			log.Warningf("Ignore invalid transaction filter %+v: %s", filter, err)
+			continue
+		}
+		filters = append(filters, filter)
+	}
+	c.TransactionSettings = filters
+
+	c.TransactionNaming.validate()
+	c.Redaction.validate()
+	c.SpanFilters = validateSpanFilters(c.SpanFilters)
+	c.TailSampling.validate()
+
 	if ok := IsValidHostnameAlias(c.HostAlias); !ok {
 		log.Warning(InvalidEnv("HostAlias", c.HostAlias))
 		c.HostAlias = getFieldDefaultValue(c, "HostAlias")
@@ -394,6 +654,17 @@ func (c *Config) validate() error {
 		c.DebugLevel = getFieldDefaultValue(c, "DebugLevel")
 	}
 
+	if _, valid := log.ToFormat(c.LogFormat); !valid {
+		log.Warning(InvalidEnv("LogFormat", c.LogFormat))
+		c.LogFormat = getFieldDefaultValue(c, "LogFormat")
+	}
+
+	if c.LogRateLimitInterval < 0 {
+		log.Warning(InvalidEnv("LogRateLimitInterval", strconv.Itoa(c.LogRateLimitInterval)))
+		i, _ := strconv.Atoi(getFieldDefaultValue(c, "LogRateLimitInterval"))
+		c.LogRateLimitInterval = i
+	}
+
 	if valid := IsValidTokenBucketCap(c.TokenBucketCap); !valid {
 		log.Warning(InvalidEnv("TokenBucketCap", fmt.Sprintf("%f", c.TokenBucketCap)))
 		if c.TokenBucketCap < 0 {
@@ -420,17 +691,10 @@ func (c *Config) Load(opts ...Option) *Config {
 	c.Lock()
 	defer c.Unlock()
 
-	c.reset()
-
-	if err := c.loadConfigFile(); err != nil {
+	if err := c.loadSources(opts, loadHooks{}); err != nil {
 		log.Warning(fmt.Errorf("config file load error: %w", err).Error())
 		return c.resetThenDisable()
 	}
-	c.loadEnvs()
-
-	for _, opt := range opts {
-		opt(c)
-	}
 
 	if !c.Enabled {
 		return c.resetThenDisable()
@@ -444,6 +708,32 @@ func (c *Config) Load(opts ...Option) *Config {
 	return c
 }
 
+// loadHooks are called by loadSources at its steps, so that Explain finds
+// where the settings come from. The nil hooks are skipped.
+type loadHooks struct {
+	// file is called with each config file loaded, converted to YAML
+	file func(path string, data []byte)
+	// options is called before the options are applied
+	options func()
+}
+
+// loadSources resets the config, then loads the config files, the
+// environment variables and the options, in this order of precedence. All
+// the config files are loaded even if one fails, and the errors are returned
+// joined. The config isn't validated.
+func (c *Config) loadSources(opts []Option, hooks loadHooks) error {
+	c.reset()
+	err := c.loadConfigFile(hooks.file)
+	c.loadEnvs()
+	if hooks.options != nil {
+		hooks.options()
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return err
+}
+
 func (c *Config) resetThenDisable() *Config {
 	c.reset()
 	c.Enabled = false
@@ -477,18 +767,22 @@ func (d *Delta) items() []DeltaItem {
 
 func (d *Delta) sanitize() *Delta {
 	for idx := range d.delta {
-		// mask the sensitive service key and proxy url
-		switch d.delta[idx].key {
-		case "ServiceKey":
-			d.delta[idx].value = MaskServiceKey(d.delta[idx].value)
-		case "Proxy":
-			d.delta[idx].value = MaskUrl(d.delta[idx].value)
-		}
-
+		d.delta[idx].value = maskValue(d.delta[idx].key, d.delta[idx].value)
 	}
 	return d
 }
 
+// maskValue masks the sensitive service key and proxy url
+func maskValue(key, value string) string {
+	switch key {
+	case "ServiceKey":
+		return MaskServiceKey(value)
+	case "Proxy":
+		return MaskUrl(value)
+	}
+	return value
+}
+
 func (d *Delta) String() string {
 	var s []string
 	for _, item := range d.delta {
@@ -681,6 +975,10 @@ func (c *Config) getConfigPath() string {
 		"./solarwinds-apm-goagent.yml",
 		"/solarwinds-apm-goagent.yaml",
 		"/solarwinds-apm-goagent.yml",
+		"./solarwinds-apm-goagent.json",
+		"./solarwinds-apm-goagent.toml",
+		"/solarwinds-apm-goagent.json",
+		"/solarwinds-apm-goagent.toml",
 	}
 
 	for _, file := range candidates {
@@ -697,19 +995,41 @@ func (c *Config) getConfigPath() string {
 	return ""
 }
 
-func (c *Config) loadYaml(path string) error {
-	data, err := os.ReadFile(path)
+// getOverrideConfigPath returns the absolute path of the file overriding the
+// config file, if any.
+func (c *Config) getOverrideConfigPath() string {
+	path, ok := os.LookupEnv(envSolarWindsAPMConfigOverrideFile)
+	if !ok || path == "" {
+		return ""
+	}
+	abs, err := filepath.Abs(path)
 	if err != nil {
-		return fmt.Errorf("loadYaml: %w", err)
+		log.Warningf("Ignore config override file %s: %s", path, err)
+		return ""
+	}
+	return abs
+}
+
+// getConfigPaths returns the absolute paths of the config file and of the
+// file overriding it, in the order they are loaded.
+func (c *Config) getConfigPaths() []string {
+	var paths []string
+	for _, path := range []string{c.getConfigPath(), c.getOverrideConfigPath()} {
+		if path != "" {
+			paths = append(paths, path)
+		}
 	}
+	return paths
+}
 
+func (c *Config) loadYaml(path string, data []byte) error {
 	// A pointer field may be assigned with nil in unmarshal, so just keep the
 	// old default value and re-assign it later.
 	origSampling := c.Sampling
 	origReporterProperties := c.ReporterProperties
 
 	// The config struct is modified in place so we won't tolerate any error
-	err = yaml.Unmarshal(data, &c)
+	err := yaml.Unmarshal(data, &c)
 	if err != nil {
 		return fmt.Errorf("loadYaml: %s: %w", path, err)
 	}
@@ -736,24 +1056,42 @@ func (c *Config) checkFileSize(path string) error {
 	return nil
 }
 
-// loadConfigFile loads configuration from the config file.
-func (c *Config) loadConfigFile() error {
-	path := c.getConfigPath()
-	if path == "" {
-		return nil
+// loadConfigFile loads configuration from the config file, then from the
+// file overriding it. The keys set in the override file replace those of the
+// config file. loaded, if not nil, is called with each file loaded.
+func (c *Config) loadConfigFile(loaded func(path string, data []byte)) error {
+	var errs []error
+	for _, path := range c.getConfigPaths() {
+		data, err := c.readFile(path)
+		if err == nil {
+			err = c.loadYaml(path, data)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if loaded != nil {
+			loaded(path, data)
+		}
 	}
+	return errors.Join(errs...)
+}
 
+// readFile reads the config file at path and converts it to YAML, after
+// expanding the environment variables its values reference.
+func (c *Config) readFile(path string) ([]byte, error) {
 	if err := c.checkFileSize(path); err != nil {
-		return fmt.Errorf("loadConfigFile: %w", err)
+		return nil, fmt.Errorf("loadConfigFile: %w", err)
 	}
-	ext := filepath.Ext(path)
-
-	switch ext {
-	case ".yml", ".yaml":
-		return c.loadYaml(path)
-	default:
-		return fmt.Errorf("%s: %w", path, ErrUnsupportedFormat)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("loadConfigFile: %w", err)
+	}
+	data, err = toYaml(path, data)
+	if err != nil {
+		return nil, fmt.Errorf("loadConfigFile: %w", err)
 	}
+	return data, nil
 }
 
 // GetCollector returns the collector address
@@ -834,6 +1172,14 @@ func (c *Config) GetPrependDomain() bool {
 	return c.PrependDomain
 }
 
+// GetTransactionMetricsLimit returns the maximum number of distinct transaction
+// names in the response time metrics per export
+func (c *Config) GetTransactionMetricsLimit() int {
+	c.RLock()
+	defer c.RUnlock()
+	return c.TransactionMetricsLimit
+}
+
 // GetHostAlias returns the host alias
 func (c *Config) GetHostAlias() string {
 	c.RLock()
@@ -848,6 +1194,20 @@ func (c *Config) GetPrecision() int {
 	return c.Precision
 }
 
+// GetHistogramUnit returns the unit of the response time histogram
+func (c *Config) GetHistogramUnit() string {
+	c.RLock()
+	defer c.RUnlock()
+	return c.HistogramUnit
+}
+
+// GetHistogramAggregation returns the aggregation of the response time histogram
+func (c *Config) GetHistogramAggregation() string {
+	c.RLock()
+	defer c.RUnlock()
+	return c.HistogramAggregation
+}
+
 // GetEnabled returns if the agent is enabled
 func (c *Config) GetEnabled() bool {
 	c.RLock()
@@ -877,6 +1237,28 @@ func (c *Config) GetDebugLevel() string {
 	return c.DebugLevel
 }
 
+// GetLogFormat returns the output format of the logs
+func (c *Config) GetLogFormat() string {
+	c.RLock()
+	defer c.RUnlock()
+	return c.LogFormat
+}
+
+// GetLogRateLimitInterval returns the interval during which a repeated log
+// message is printed at most once
+func (c *Config) GetLogRateLimitInterval() time.Duration {
+	c.RLock()
+	defer c.RUnlock()
+	return time.Duration(c.LogRateLimitInterval) * time.Second
+}
+
+// GetConfigReloadInterval returns the interval to check the config file for changes
+func (c *Config) GetConfigReloadInterval() time.Duration {
+	c.RLock()
+	defer c.RUnlock()
+	return time.Duration(c.ConfigReloadInterval) * time.Second
+}
+
 // GetTriggerTrace returns the trigger trace configuration
 func (c *Config) GetTriggerTrace() bool {
 	c.RLock()
@@ -891,6 +1273,29 @@ func (c *Config) GetProxy() string {
 	return c.Proxy
 }
 
+// GetSettingsCacheFile returns the path of the sampling settings cache file
+func (c *Config) GetSettingsCacheFile() string {
+	c.RLock()
+	defer c.RUnlock()
+	return c.SettingsCacheFile
+}
+
+// GetSettingsStartupJitter returns the maximum delay of the first fetch of
+// the sampling settings
+func (c *Config) GetSettingsStartupJitter() time.Duration {
+	c.RLock()
+	defer c.RUnlock()
+	return time.Duration(c.SettingsStartupJitter) * time.Second
+}
+
+// GetSettingsUpdateJitter returns the ratio of the settings update interval
+// by which each update is randomly advanced or delayed
+func (c *Config) GetSettingsUpdateJitter() float64 {
+	c.RLock()
+	defer c.RUnlock()
+	return float64(c.SettingsUpdateJitter) / 100
+}
+
 // GetProxyCertPath returns the proxy's certificate path
 func (c *Config) GetProxyCertPath() string {
 	c.RLock()
@@ -933,6 +1338,35 @@ func (c *Config) GetTransactionFiltering() []TransactionFilter {
 	return c.TransactionSettings
 }
 
+// GetTransactionNaming returns the rules deriving the transaction names from
+// the URL paths
+func (c *Config) GetTransactionNaming() TransactionNamingConfig {
+	c.RLock()
+	defer c.RUnlock()
+	return c.TransactionNaming.clone()
+}
+
+// GetRedaction returns the span attributes redacted before export
+func (c *Config) GetRedaction() RedactionConfig {
+	c.RLock()
+	defer c.RUnlock()
+	return c.Redaction.clone()
+}
+
+// GetSpanFilters returns the filters dropping the spans before export
+func (c *Config) GetSpanFilters() []SpanFilter {
+	c.RLock()
+	defer c.RUnlock()
+	return cloneSpanFilters(c.SpanFilters)
+}
+
+// GetTailSampling returns the buffering of the traces which are not sampled
+func (c *Config) GetTailSampling() TailSamplingConfig {
+	c.RLock()
+	defer c.RUnlock()
+	return c.TailSampling
+}
+
 // GetTransactionName returns the user-defined transaction name. It's only available
 // in the AWS Lambda environment.
 func (c *Config) GetTransactionName() string {
@@ -0,0 +1,299 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Source is where the effective value of a setting comes from
+type Source string
+
+const (
+	// SourceDefault is the default value of the setting
+	SourceDefault Source = "default"
+	// SourceFile is the config file or the file overriding it
+	SourceFile Source = "file"
+	// SourceEnv is an environment variable
+	SourceEnv Source = "env"
+	// SourceOption is an option given in code
+	SourceOption Source = "option"
+)
+
+// Explain errors
+var (
+	// ErrUnknownKey is reported for the keys of the config file which don't
+	// match any setting
+	ErrUnknownKey = errors.New("unknown key")
+	// ErrInvalidValue is reported for the values the agent rejects, which are
+	// replaced with the default values or ignored
+	ErrInvalidValue = errors.New("invalid value")
+)
+
+// Entry is the effective value of a setting
+type Entry struct {
+	// Key is the dotted path of the setting, e.g. `Sampling.SampleRate`
+	Key string
+	// Value is the effective value, the sensitive values are masked
+	Value  string
+	Source Source
+	// Origin is the path of the file or the name of the environment variable
+	// setting the value, if any
+	Origin string
+}
+
+// origin is where a setting is set
+type origin struct {
+	source Source
+	name   string
+}
+
+// origins are where the settings are set, keyed by the settings
+type origins map[string]origin
+
+func (s origins) of(key string) origin {
+	if o, ok := s[key]; ok {
+		return o
+	}
+	return origin{source: SourceDefault}
+}
+
+func (o origin) String() string {
+	if o.name == "" {
+		return string(o.source)
+	}
+	return fmt.Sprintf("%s %s", o.source, o.name)
+}
+
+// Explain loads the configuration the same way as Load, from the config files,
+// the environment variables and the options, and returns the effective value
+// of every setting with its source. The error joins all the problems found:
+// the files which can't be loaded, the unknown keys of the config files, the
+// invalid values and the missing service key. It's nil if the configuration
+// is valid.
+func Explain(opts ...Option) ([]Entry, error) {
+	c := newConfig()
+	set := origins{}
+	var errs []error
+	var before map[string]string
+
+	err := c.loadSources(opts, loadHooks{
+		file: func(path string, data []byte) {
+			var tree map[interface{}]interface{}
+			_ = yaml.Unmarshal(data, &tree)
+			keys, unknown := fileKeys(reflect.TypeOf(c).Elem(), tree, "")
+			for _, key := range keys {
+				set[key] = origin{SourceFile, path}
+			}
+			for _, key := range unknown {
+				errs = append(errs, fmt.Errorf("%s: %s: %w", path, key, ErrUnknownKey))
+			}
+		},
+		options: func() {
+			before = c.values()
+		},
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, l := range leaves(c, "") {
+		name := l.field.Tag.Get("env")
+		val := os.Getenv(name)
+		if name == "" || val == "" {
+			continue
+		}
+		if _, err := stringToValue(val, l.field.Type); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w %q from env %s, ignored", l.key, ErrInvalidValue, val, name))
+			continue
+		}
+		set[l.key] = origin{SourceEnv, name}
+	}
+
+	configured := c.values()
+	for key, val := range configured {
+		if val != before[key] {
+			set[key] = origin{source: SourceOption}
+		}
+	}
+
+	err = c.validate()
+	if errors.Is(err, ErrInvalidServiceKey) {
+		errs = append(errs, fmt.Errorf("ServiceKey: %w %s from %s, expected <api token>:<service name>",
+			ErrInvalidValue, MaskServiceKey(c.ServiceKey), set.of("ServiceKey")))
+		// validate stops at the invalid service key, run it again to check
+		// the other settings
+		key := c.ServiceKey
+		c.ServiceKey = ""
+		err = c.validate()
+		c.ServiceKey = key
+	}
+	if err != nil && !errors.Is(err, ErrInvalidServiceKey) {
+		errs = append(errs, err)
+	}
+	if c.ServiceKey == "" && c.Enabled && !HasLambdaEnv() {
+		errs = append(errs, fmt.Errorf("ServiceKey: %w: the service key is required", ErrInvalidValue))
+	}
+
+	var entries []Entry
+	for _, l := range leaves(c, "") {
+		o := set.of(l.key)
+		val := formatValue(l.value)
+		// The service key is normalized by the validation, and checked above
+		if prev := configured[l.key]; prev != val && l.key != "ServiceKey" {
+			errs = append(errs, fmt.Errorf("%s: %w %s from %s, the agent uses %s",
+				l.key, ErrInvalidValue, maskValue(l.key, prev), o, maskValue(l.key, val)))
+		}
+		entries = append(entries, Entry{
+			Key:    l.key,
+			Value:  maskValue(l.key, val),
+			Source: o.source,
+			Origin: o.name,
+		})
+	}
+	return entries, errors.Join(errs...)
+}
+
+// leaf is a setting, i.e. a field of the config which isn't a struct
+type leaf struct {
+	key   string
+	field reflect.StructField
+	value reflect.Value
+}
+
+// leaves returns the settings of the struct v, a pointer to a struct, in the
+// order they're defined
+func leaves(v interface{}, prefix string) []leaf {
+	val := reflect.Indirect(reflect.ValueOf(v))
+	var ls []leaf
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Type().Field(i)
+		if field.Anonymous || !field.IsExported() {
+			continue
+		}
+		key := joinKey(prefix, field.Name)
+		fieldVal := reflect.Indirect(val.Field(i))
+		if fieldVal.Kind() == reflect.Struct {
+			ls = append(ls, leaves(getValPtr(val.Field(i)).Interface(), key)...)
+			continue
+		}
+		ls = append(ls, leaf{key: key, field: field, value: fieldVal})
+	}
+	return ls
+}
+
+// values returns the formatted values of the settings
+func (c *Config) values() map[string]string {
+	vals := map[string]string{}
+	for _, l := range leaves(c, "") {
+		vals[l.key] = formatValue(l.value)
+	}
+	return vals
+}
+
+// formatValue formats the scalar values as is and the others as JSON, so that
+// the pointers they hold are dereferenced
+func formatValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Struct:
+		if b, err := json.Marshal(v.Interface()); err == nil {
+			return string(b)
+		}
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+// fileKeys returns the keys of the settings set by the config file, whose
+// content is tree, and its unknown keys. The keys of the elements of the
+// lists of structs, e.g. TransactionSettings, are checked but the list is
+// reported as a single setting.
+func fileKeys(typ reflect.Type, tree map[interface{}]interface{}, prefix string) (keys, unknown []string) {
+	names := make([]interface{}, 0, len(tree))
+	for k := range tree {
+		names = append(names, k)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return fmt.Sprint(names[i]) < fmt.Sprint(names[j])
+	})
+
+	for _, k := range names {
+		name := fmt.Sprint(k)
+		field, ok := yamlField(typ, name)
+		if !ok {
+			unknown = append(unknown, joinKey(prefix, name))
+			continue
+		}
+		key := joinKey(prefix, field.Name)
+		value := tree[k]
+		ft := field.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if sub, ok := value.(map[interface{}]interface{}); ok && ft.Kind() == reflect.Struct {
+			k, u := fileKeys(ft, sub, key)
+			keys = append(keys, k...)
+			unknown = append(unknown, u...)
+			continue
+		}
+		keys = append(keys, key)
+		if list, ok := value.([]interface{}); ok && ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.Struct {
+			for i, elem := range list {
+				if sub, ok := elem.(map[interface{}]interface{}); ok {
+					_, u := fileKeys(ft.Elem(), sub, fmt.Sprintf("%s[%d]", key, i))
+					unknown = append(unknown, u...)
+				}
+			}
+		}
+	}
+	return keys, unknown
+}
+
+// yamlField returns the field of the struct type typ decoded from the YAML
+// key name
+func yamlField(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Anonymous || !field.IsExported() {
+			continue
+		}
+		tag, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if tag == "-" {
+			continue
+		}
+		if tag == "" {
+			// The default key of the yaml package
+			tag = strings.ToLower(field.Name)
+		}
+		if tag == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func joinKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
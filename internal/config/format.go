@@ -0,0 +1,241 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+var envNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// interpolate expands the `${VAR}` and `${VAR:-default}` references to the
+// environment variables in the string values of tree, which is decoded from a
+// config file into typ. The keys aren't expanded. The expanded values are
+// kept as strings, except those of the number and boolean settings which are
+// parsed, as YAML doesn't decode a quoted string into them.
+func interpolate(tree interface{}, typ reflect.Type, lookupEnv func(string) (string, bool)) interface{} {
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch v := tree.(type) {
+	case string:
+		val, expanded := interpolateString(v, lookupEnv)
+		if expanded {
+			return parseValue(val, typ)
+		}
+		return val
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = interpolate(e, fieldType(typ, k), lookupEnv)
+		}
+	case map[interface{}]interface{}:
+		for k, e := range v {
+			v[k] = interpolate(e, fieldType(typ, fmt.Sprint(k)), lookupEnv)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = interpolate(e, elemType(typ), lookupEnv)
+		}
+	case []map[string]interface{}:
+		for _, e := range v {
+			interpolate(e, elemType(typ), lookupEnv)
+		}
+	}
+	return tree
+}
+
+// fieldType returns the type of the value of key in typ, or nil if unknown
+func fieldType(typ reflect.Type, key string) reflect.Type {
+	if typ == nil {
+		return nil
+	}
+	switch typ.Kind() {
+	case reflect.Struct:
+		if field, ok := yamlField(typ, key); ok {
+			return field.Type
+		}
+	case reflect.Map:
+		return typ.Elem()
+	}
+	return nil
+}
+
+// elemType returns the type of the elements of typ, or nil if unknown
+func elemType(typ reflect.Type) reflect.Type {
+	if typ != nil && (typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array) {
+		return typ.Elem()
+	}
+	return nil
+}
+
+// parseValue parses val as a value of typ if it's a number or a boolean type.
+// The other values, and those which can't be parsed, are kept as is.
+func parseValue(val string, typ reflect.Type) interface{} {
+	if typ == nil {
+		return val
+	}
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, err := strconv.ParseInt(val, 10, 64); err == nil {
+			return i
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u, err := strconv.ParseUint(val, 10, 64); err == nil {
+			return u
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(val, 64); err == nil {
+			return f
+		}
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(val); err == nil {
+			return b
+		}
+	}
+	return val
+}
+
+// interpolateString expands the `${VAR}` and `${VAR:-default}` references in
+// s. The default value is used when VAR is unset or empty, and `$${` escapes a
+// reference. The references to unset variables without a default, and those
+// which aren't variable names, are kept as is so that the `${1}` capture group
+// references of the transaction naming rules are preserved. It also returns
+// whether any reference is expanded.
+func interpolateString(s string, lookupEnv func(string) (string, bool)) (string, bool) {
+	var b strings.Builder
+	expanded := false
+	for i := 0; i < len(s); {
+		if strings.HasPrefix(s[i:], "$${") {
+			b.WriteString("${")
+			i += 3
+			continue
+		}
+		end := -1
+		if strings.HasPrefix(s[i:], "${") {
+			end = strings.IndexAny(s[i+2:], "}\n")
+		}
+		if end < 0 || s[i+2+end] != '}' {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+		ref := s[i : i+3+end]
+		name, def, hasDefault := strings.Cut(ref[2:len(ref)-1], ":-")
+		val, ok := lookupEnv(name)
+		switch {
+		case !envNameRegex.MatchString(name):
+			val = ref
+		case (!ok || val == "") && hasDefault:
+			val, expanded = def, true
+		case !ok:
+			val = ref
+		default:
+			expanded = true
+		}
+		b.WriteString(val)
+		i += len(ref)
+	}
+	return b.String(), expanded
+}
+
+// toYaml decodes the content of the config file at path, in the format given
+// by its extension, expands the environment variables its values reference
+// and converts it to YAML so that all the formats are decoded with the same
+// struct tags
+func toYaml(path string, data []byte) ([]byte, error) {
+	var tree interface{}
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yml", ".yaml":
+		tree, err = decodeYaml(data)
+	case ".json":
+		tree, err = decodeJSON(data)
+	case ".toml":
+		tree, err = decodeTOML(data)
+	default:
+		return nil, fmt.Errorf("%s: %w", path, ErrUnsupportedFormat)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return yaml.Marshal(interpolate(tree, reflect.TypeOf((*Config)(nil)), os.LookupEnv))
+}
+
+// decodeYaml decodes a YAML document. An empty document is decoded as an
+// empty map.
+func decodeYaml(data []byte) (map[interface{}]interface{}, error) {
+	tree := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// decodeTOML decodes a TOML document
+func decodeTOML(data []byte) (map[string]interface{}, error) {
+	var tree map[string]interface{}
+	if _, err := toml.Decode(string(data), &tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// decodeJSON decodes a JSON object, keeping the integers as int64 rather than
+// float64
+func decodeJSON(data []byte) (map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var tree map[string]interface{}
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("invalid data after the top-level object")
+	}
+	return normalizeJSON(tree).(map[string]interface{}), nil
+}
+
+func normalizeJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = normalizeJSON(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = normalizeJSON(e)
+		}
+	}
+	return v
+}
@@ -0,0 +1,260 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpolate(t *testing.T) {
+	env := map[string]string{"HOST": "example.com", "EMPTY": "", "RATE": "100", "ON": "true", "ALIAS": "007"}
+	lookupEnv := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+	for _, test := range []struct {
+		in  string
+		out interface{}
+	}{
+		{"${HOST}", "example.com"},
+		{"${HOST:-default.com}:443", "example.com:443"},
+		{"${MISSING:-default.com}", "default.com"},
+		{"${EMPTY:-default.com}", "default.com"},
+		{"${MISSING:-}", ""},
+		{"${EMPTY}", ""},
+		{"${MISSING}", "${MISSING}"},
+		{"/api/v${1}/${2}", "/api/v${1}/${2}"},
+		{"${HOST", "${HOST"},
+		{"${HOST\n}", "${HOST\n}"},
+		{"^/api$", "^/api$"},
+		{"$${HOST}", "${HOST}"},
+		{"${HOST}${HOST}$", "example.comexample.com$"},
+		// The values of the string settings stay strings
+		{"${RATE}", "100"},
+		{"${ALIAS}", "007"},
+		{"${MISSING:-1e5}", "1e5"},
+	} {
+		assert.Equal(t, test.out, interpolate(test.in, nil, lookupEnv), test.in)
+	}
+
+	type sub struct {
+		Rate  float64 `yaml:"Rate"`
+		Alias string  `yaml:"Alias"`
+	}
+	type settings struct {
+		Rate    int    `yaml:"Rate"`
+		Enabled bool   `yaml:"Enabled"`
+		Alias   string `yaml:"Alias"`
+		Sub     *sub   `yaml:"Sub"`
+		List    []sub  `yaml:"List"`
+		Tables  []sub  `yaml:"Tables"`
+	}
+	tree := map[interface{}]interface{}{
+		"Rate":     "${RATE}",
+		"Enabled":  "${ON}",
+		"Alias":    "${ALIAS}",
+		"Sub":      map[interface{}]interface{}{"Rate": "${MISSING:-2.5}", "Alias": "${RATE}"},
+		"List":     []interface{}{map[string]interface{}{"Rate": "${RATE}", "Alias": "${ALIAS}"}},
+		"Tables":   []map[string]interface{}{{"Rate": "${ON}"}},
+		"${HOST}":  "${RATE}",
+		"Constant": int64(1),
+	}
+	assert.Equal(t, map[interface{}]interface{}{
+		"Rate":    int64(100),
+		"Enabled": true,
+		"Alias":   "007",
+		"Sub":     map[interface{}]interface{}{"Rate": 2.5, "Alias": "100"},
+		"List":    []interface{}{map[string]interface{}{"Rate": 100.0, "Alias": "007"}},
+		// Not a number, left to the decoder to reject
+		"Tables": []map[string]interface{}{{"Rate": "true"}},
+		// The keys aren't expanded, and the unknown keys are kept as strings
+		"${HOST}":  "100",
+		"Constant": int64(1),
+	}, interpolate(tree, reflect.TypeOf(settings{}), lookupEnv))
+}
+
+func TestDecodeTOML(t *testing.T) {
+	tree, err := decodeTOML([]byte(`
+Collector = "collector.example.com:443" # comment
+SampleRate = 1_000
+Rate = 2.5
+
+[Sampling]
+TracingMode = "enabled"
+
+[[TransactionSettings]]
+Type = "url"
+RegEx = '\d+'
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"Collector":  "collector.example.com:443",
+		"SampleRate": int64(1000),
+		"Rate":       2.5,
+		"Sampling":   map[string]interface{}{"TracingMode": "enabled"},
+		"TransactionSettings": []map[string]interface{}{
+			{"Type": "url", "RegEx": `\d+`},
+		},
+	}, tree)
+
+	_, err = decodeTOML([]byte("A = 1\nA = 2"))
+	assert.Error(t, err)
+}
+
+func TestDecodeJSON(t *testing.T) {
+	tree, err := decodeJSON([]byte(`{"SampleRate": 100, "TokenBucketRate": 2.5, "List": [1, {"A": 2}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"SampleRate":      int64(100),
+		"TokenBucketRate": 2.5,
+		"List":            []interface{}{int64(1), map[string]interface{}{"A": int64(2)}},
+	}, tree)
+
+	_, err = decodeJSON([]byte(`{"A": 1} {"B": 2}`))
+	assert.Error(t, err)
+	_, err = decodeJSON([]byte(`[1]`))
+	assert.Error(t, err)
+}
+
+const formatTestYaml = `
+Collector: ${TEST_COLLECTOR:-collector.example.com}
+ServiceKey: ae38315f6116585d64d82ec2455aa3ec61e02fee25d286f74ace9e4fea189218:go
+Sampling:
+  TracingMode: disabled
+  SampleRate: 100
+TokenBucketRate: 2.5
+TransactionSettings:
+  - Type: url
+    Extensions: [png, jpg]
+    Tracing: disabled
+`
+
+const formatTestJSON = `{
+  "Collector": "${TEST_COLLECTOR:-collector.example.com}",
+  "ServiceKey": "ae38315f6116585d64d82ec2455aa3ec61e02fee25d286f74ace9e4fea189218:go",
+  "Sampling": {"TracingMode": "disabled", "SampleRate": 100},
+  "TokenBucketRate": 2.5,
+  "TransactionSettings": [
+    {"Type": "url", "Extensions": ["png", "jpg"], "Tracing": "disabled"}
+  ]
+}`
+
+const formatTestTOML = `
+Collector = "${TEST_COLLECTOR:-collector.example.com}"
+ServiceKey = "ae38315f6116585d64d82ec2455aa3ec61e02fee25d286f74ace9e4fea189218:go"
+TokenBucketRate = 2.5
+
+[Sampling]
+TracingMode = "disabled"
+SampleRate = 100
+
+[[TransactionSettings]]
+Type = "url"
+Extensions = ["png", "jpg"]
+Tracing = "disabled"
+`
+
+func TestConfigFileFormats(t *testing.T) {
+	dir := t.TempDir()
+	var configs []*Config
+	for name, content := range map[string]string{
+		"config.yaml": formatTestYaml,
+		"config.json": formatTestJSON,
+		"config.toml": formatTestTOML,
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		ClearEnvs()
+		t.Setenv(envSolarWindsAPMConfigFile, path)
+		t.Setenv("TEST_COLLECTOR", "interpolated.example.com")
+
+		c := NewConfig()
+		require.True(t, c.GetEnabled(), name)
+		assert.Equal(t, "interpolated.example.com:443", c.GetCollector(), name)
+		assert.Equal(t, 100, c.GetSampleRate(), name)
+		assert.Equal(t, DisabledTracingMode, c.GetTracingMode(), name)
+		assert.Equal(t, 2.5, c.GetTokenBucketRate(), name)
+		assert.Len(t, c.GetTransactionFiltering(), 1, name)
+		configs = append(configs, c)
+	}
+	assert.Equal(t, configs[0], configs[1])
+	assert.Equal(t, configs[0], configs[2])
+}
+
+func TestConfigFileInterpolationIsNotParsed(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"config.yaml": "TrustedPath: ${TEST_PATH}\nServiceKey: ${TEST_SERVICE_KEY}\nHostAlias: ${TEST_ALIAS}\nSampling:\n  SampleRate: ${TEST_RATE}\n",
+		"config.json": `{"TrustedPath": "${TEST_PATH}", "ServiceKey": "${TEST_SERVICE_KEY}", "HostAlias": "${TEST_ALIAS}", "Sampling": {"SampleRate": "${TEST_RATE}"}}`,
+		"config.toml": "TrustedPath = \"${TEST_PATH}\"\nServiceKey = \"${TEST_SERVICE_KEY}\"\nHostAlias = \"${TEST_ALIAS}\"\n[Sampling]\nSampleRate = \"${TEST_RATE}\"\n",
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		ClearEnvs()
+		t.Setenv(envSolarWindsAPMConfigFile, path)
+		// The values can't change the structure of the file
+		t.Setenv("TEST_PATH", `/tmp/ca.pem", "Enabled": false, "X": "y # z`)
+		t.Setenv("TEST_SERVICE_KEY", "ae38315f6116585d64d82ec2455aa3ec61e02fee25d286f74ace9e4fea189218:go")
+		// Decoded as the type of the setting
+		t.Setenv("TEST_ALIAS", "007")
+		t.Setenv("TEST_RATE", "100")
+
+		c := NewConfig()
+		require.True(t, c.GetEnabled(), name)
+		assert.Equal(t, `/tmp/ca.pem", "Enabled": false, "X": "y # z`, c.GetTrustedPath(), name)
+		assert.Equal(t, "007", c.GetHostAlias(), name)
+		assert.Equal(t, 100, c.GetSampleRate(), name)
+	}
+}
+
+func TestConfigOverrideFile(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "solarwinds-apm-goagent.yaml")
+	override := filepath.Join(dir, "solarwinds-apm-goagent.production.toml")
+	require.NoError(t, os.WriteFile(base, []byte(formatTestYaml), 0644))
+	require.NoError(t, os.WriteFile(override, []byte(`
+DebugLevel = "${TEST_LEVEL:-error}"
+
+[Sampling]
+SampleRate = 5000
+`), 0644))
+
+	ClearEnvs()
+	t.Setenv(envSolarWindsAPMConfigFile, base)
+	t.Setenv(envSolarWindsAPMConfigOverrideFile, override)
+	c := NewConfig()
+	require.True(t, c.GetEnabled())
+	// Overridden
+	assert.Equal(t, 5000, c.GetSampleRate())
+	assert.Equal(t, "error", c.GetDebugLevel())
+	// Inherited from the base file
+	assert.Equal(t, DisabledTracingMode, c.GetTracingMode())
+	assert.Equal(t, "collector.example.com:443", c.GetCollector())
+	assert.Equal(t, []string{base, override}, c.getConfigPaths())
+
+	// The environment variables still take precedence
+	t.Setenv("SW_APM_SAMPLE_RATE", "42")
+	assert.Equal(t, 42, NewConfig().GetSampleRate())
+
+	// A missing override file is an error, as for the config file
+	t.Setenv(envSolarWindsAPMConfigOverrideFile, filepath.Join(dir, "missing.toml"))
+	assert.False(t, NewConfig().GetEnabled())
+}
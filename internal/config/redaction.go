@@ -0,0 +1,78 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"path"
+	"regexp"
+	"slices"
+
+	"github.com/solarwinds/apm-go/internal/log"
+)
+
+// RedactionConfig defines the span attributes whose values are redacted
+// before export.
+type RedactionConfig struct {
+	// The keys of the attributes to redact entirely. A key is matched either
+	// exactly or as a glob pattern, e.g. `*.password`.
+	Keys []string `yaml:"Keys,omitempty"`
+	// The regular expressions matching the sensitive parts of the attribute
+	// values, e.g. email addresses or card numbers. Only the matching parts
+	// are redacted.
+	Values []string `yaml:"Values,omitempty"`
+}
+
+// IsEmpty returns if nothing is redacted
+func (r RedactionConfig) IsEmpty() bool {
+	return len(r.Keys) == 0 && len(r.Values) == 0
+}
+
+// CompiledValues returns the compiled regular expressions of Values. The
+// invalid ones are ignored.
+func (r RedactionConfig) CompiledValues() []*regexp.Regexp {
+	var res []*regexp.Regexp
+	for _, v := range r.Values {
+		if re, err := regexp.Compile(v); err == nil {
+			res = append(res, re)
+		}
+	}
+	return res
+}
+
+// validate drops the invalid key patterns and value regular expressions
+func (r *RedactionConfig) validate() {
+	r.Keys = slices.DeleteFunc(r.Keys, func(k string) bool {
+		if _, err := path.Match(k, ""); err != nil {
+			log.Warningf("Ignore invalid redaction key %q: %s", k, err)
+			return true
+		}
+		return false
+	})
+	r.Values = slices.DeleteFunc(r.Values, func(v string) bool {
+		if _, err := regexp.Compile(v); err != nil {
+			log.Warningf("Ignore invalid redaction value %q: %s", v, err)
+			return true
+		}
+		return false
+	})
+}
+
+// clone returns a deep copy so that callers can't modify the config
+func (r RedactionConfig) clone() RedactionConfig {
+	return RedactionConfig{
+		Keys:   slices.Clone(r.Keys),
+		Values: slices.Clone(r.Values),
+	}
+}
@@ -0,0 +1,52 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactionConfigValidate(t *testing.T) {
+	r := RedactionConfig{
+		Keys:   []string{"password", "*.secret", "[invalid"},
+		Values: []string{`\d{16}`, `(invalid`},
+	}
+	r.validate()
+	assert.Equal(t, []string{"password", "*.secret"}, r.Keys)
+	assert.Equal(t, []string{`\d{16}`}, r.Values)
+	require.Len(t, r.CompiledValues(), 1)
+	assert.True(t, r.CompiledValues()[0].MatchString("4111111111111111"))
+
+	assert.True(t, RedactionConfig{}.IsEmpty())
+	assert.False(t, r.IsEmpty())
+}
+
+func TestWithRedaction(t *testing.T) {
+	ClearEnvs()
+	c := NewConfig(
+		WithRedaction(RedactionConfig{Keys: []string{"password"}}),
+		WithRedaction(RedactionConfig{Keys: []string{"token"}, Values: []string{`[a-z]+@[a-z]+\.com`, `(`}}),
+	)
+	r := c.GetRedaction()
+	assert.Equal(t, []string{"password", "token"}, r.Keys)
+	assert.Equal(t, []string{`[a-z]+@[a-z]+\.com`}, r.Values)
+
+	// The returned config is a copy
+	r.Keys[0] = "changed"
+	assert.Equal(t, "password", c.GetRedaction().Keys[0])
+}
@@ -0,0 +1,199 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/solarwinds/apm-go/internal/log"
+)
+
+// reloadable holds the settings which are applied without a restart when the
+// config file changes
+type reloadable struct {
+	Sampling             *SamplingConfig
+	TransactionSettings  []TransactionFilter
+	DebugLevel           string
+	LogRateLimitInterval int
+}
+
+func (c *Config) reloadable() *reloadable {
+	return &reloadable{
+		Sampling:             c.Sampling,
+		TransactionSettings:  c.TransactionSettings,
+		DebugLevel:           c.DebugLevel,
+		LogRateLimitInterval: c.LogRateLimitInterval,
+	}
+}
+
+// Reload reads the config file and the environment variables again and
+// applies the options, which should be those given to Load. Only Sampling,
+// TransactionSettings, DebugLevel and LogRateLimitInterval are updated, the
+// other settings require a restart. It returns the changes, or an error if the reloaded config is
+// invalid, in which case the current config is kept.
+func (c *Config) Reload(opts ...Option) (*Delta, error) {
+	next := newConfig()
+	if err := next.loadSources(opts, loadHooks{}); err != nil {
+		return nil, err
+	}
+	if err := next.validateReloadable(); err != nil {
+		return nil, err
+	}
+	if err := next.validate(); err != nil {
+		return nil, err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	delta := getDelta(c.reloadable(), next.reloadable(), "")
+	c.Sampling = next.Sampling
+	c.TransactionSettings = next.TransactionSettings
+	c.DebugLevel = next.DebugLevel
+	c.LogRateLimitInterval = next.LogRateLimitInterval
+	return delta, nil
+}
+
+// validateReloadable rejects the invalid reloadable settings, which are
+// otherwise reset to their default values by validate
+func (c *Config) validateReloadable() error {
+	if !IsValidTracingMode(c.Sampling.TracingMode) {
+		return fmt.Errorf("Sampling.TracingMode: %q: %w", c.Sampling.TracingMode, ErrInvalidReloadedConfig)
+	}
+	if !IsValidSampleRate(c.Sampling.SampleRate) {
+		return fmt.Errorf("Sampling.SampleRate: %d: %w", c.Sampling.SampleRate, ErrInvalidReloadedConfig)
+	}
+	for _, filter := range c.TransactionSettings {
+		if err := filter.validate(); err != nil {
+			return fmt.Errorf("TransactionSettings: %+v: %w: %w", filter, err, ErrInvalidReloadedConfig)
+		}
+	}
+	if _, ok := log.ToLogLevel(c.DebugLevel); !ok {
+		return fmt.Errorf("DebugLevel: %q: %w", c.DebugLevel, ErrInvalidReloadedConfig)
+	}
+	if c.LogRateLimitInterval < 0 {
+		return fmt.Errorf("LogRateLimitInterval: %d: %w", c.LogRateLimitInterval, ErrInvalidReloadedConfig)
+	}
+	return nil
+}
+
+// Changed returns if the item of the given key, e.g. `DebugLevel`, is part
+// of the delta
+func (d *Delta) Changed(key string) bool {
+	for _, item := range d.delta {
+		if item.key == key || strings.HasPrefix(item.key, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// changes returns the delta of two configs as the items changed from the
+// first one
+func (d *Delta) changes() string {
+	var s []string
+	for _, item := range d.delta {
+		s = append(s, fmt.Sprintf(" - %s = %s (was: %s)", item.key, item.value, item.defaultVal))
+	}
+	return strings.Join(s, "\n")
+}
+
+// WatchConfigFile checks the config file and the file overriding it for
+// changes periodically and reloads the global config when one of them is
+// modified, see Config.Reload. The
+// function onReload is called with the delta once the changes are applied.
+// It returns a function stopping the watcher, and doesn't watch anything if
+// there is no config file or the reload interval is 0.
+func WatchConfigFile(onReload func(*Delta), opts ...Option) (stop func()) {
+	return conf.watchFile(conf.GetConfigReloadInterval(), onReload, opts...)
+}
+
+func (c *Config) watchFile(interval time.Duration, onReload func(*Delta), opts ...Option) func() {
+	paths := c.getConfigPaths()
+	if len(paths) == 0 || interval <= 0 {
+		return func() {}
+	}
+	last := make([]os.FileInfo, len(paths))
+	missing := make([]bool, len(paths))
+	for i, path := range paths {
+		last[i], _ = os.Stat(path)
+	}
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if modified := checkModified(paths, last, missing); modified != "" {
+					c.reloadFile(modified, onReload, opts...)
+				}
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// checkModified returns the first of the files modified since they were last
+// checked, or an empty string, and updates last with their current state. The
+// files which can't be checked are marked in missing, so that it's logged
+// once rather than on every check, and once more when they're back.
+func checkModified(paths []string, last []os.FileInfo, missing []bool) string {
+	modified := ""
+	for i, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			if !missing[i] {
+				log.Warningf("Failed to check the config file %s, the current config is kept until it's back: %s", path, err)
+				missing[i] = true
+			}
+			continue
+		}
+		if missing[i] {
+			log.Infof("The config file %s is back", path)
+			missing[i] = false
+		}
+		if last[i] != nil && info.ModTime().Equal(last[i].ModTime()) && info.Size() == last[i].Size() {
+			continue
+		}
+		last[i] = info
+		if modified == "" {
+			modified = path
+		}
+	}
+	return modified
+}
+
+func (c *Config) reloadFile(path string, onReload func(*Delta), opts ...Option) {
+	delta, err := c.Reload(opts...)
+	if err != nil {
+		log.Warningf("Rejected the changes of the config file %s, keeping the current config: %s", path, err)
+		return
+	}
+	if len(delta.items()) == 0 {
+		log.Debugf("Reloaded the config file %s, no changes to apply", path)
+		return
+	}
+	log.Infof("Reloaded the config file %s, changed items:\n%s", path, delta.changes())
+	if onReload != nil {
+		onReload(delta)
+	}
+}
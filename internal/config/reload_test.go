@@ -0,0 +1,199 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/solarwinds/apm-go/internal/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeReloadTestConfig(t *testing.T, path string, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func newReloadTestConfig(t *testing.T) (*Config, string) {
+	ClearEnvs()
+	path := filepath.Join(t.TempDir(), "solarwinds-apm-goagent.yaml")
+	writeReloadTestConfig(t, path, `
+Collector: collector.example.com
+DebugLevel: info
+Sampling:
+  SampleRate: 500000
+`)
+	t.Setenv(envSolarWindsAPMConfigFile, path)
+	return NewConfig(), path
+}
+
+func TestReload(t *testing.T) {
+	c, path := newReloadTestConfig(t)
+	require.Equal(t, 500000, c.GetSampleRate())
+	require.Equal(t, "info", c.GetDebugLevel())
+
+	writeReloadTestConfig(t, path, `
+Collector: other.example.com
+DebugLevel: debug
+Sampling:
+  SampleRate: 100
+  TracingMode: never
+TransactionSettings:
+  - Type: url
+    Extensions: [png]
+    Tracing: disabled
+`)
+	delta, err := c.Reload()
+	require.NoError(t, err)
+	assert.True(t, delta.Changed("Sampling"))
+	assert.True(t, delta.Changed("Sampling.SampleRate"))
+	assert.True(t, delta.Changed("TransactionSettings"))
+	assert.True(t, delta.Changed("DebugLevel"))
+	assert.False(t, delta.Changed("Collector"))
+	assert.Contains(t, delta.changes(), " - Sampling.SampleRate = 100 (was: 500000)")
+
+	assert.Equal(t, 100, c.GetSampleRate())
+	assert.Equal(t, DisabledTracingMode, c.GetTracingMode())
+	assert.Equal(t, "debug", c.GetDebugLevel())
+	assert.Len(t, c.GetTransactionFiltering(), 1)
+	// The other settings require a restart
+	assert.Equal(t, "collector.example.com:443", c.GetCollector())
+
+	// Nothing changed
+	delta, err = c.Reload()
+	require.NoError(t, err)
+	assert.Empty(t, delta.items())
+}
+
+func TestReloadRejectsInvalidConfig(t *testing.T) {
+	c, path := newReloadTestConfig(t)
+
+	for _, content := range []string{
+		"Sampling:\n  SampleRate: 2000000\n",
+		"Sampling:\n  TracingMode: sometimes\n",
+		"DebugLevel: verbose\n",
+		"TransactionSettings:\n  - Type: url\n    Tracing: disabled\n",
+		"Sampling: [invalid yaml",
+	} {
+		writeReloadTestConfig(t, path, content)
+		_, err := c.Reload()
+		assert.Error(t, err, content)
+		assert.Equal(t, 500000, c.GetSampleRate())
+		assert.Equal(t, "info", c.GetDebugLevel())
+	}
+}
+
+func TestReloadKeepsOptions(t *testing.T) {
+	c, path := newReloadTestConfig(t)
+	writeReloadTestConfig(t, path, "Sampling:\n  SampleRate: 100\nDebugLevel: debug\n")
+	delta, err := c.Reload(WithSampleRate(10))
+	require.NoError(t, err)
+	assert.Equal(t, 10, c.GetSampleRate())
+	assert.False(t, delta.Changed("Collector"))
+	assert.True(t, delta.Changed("DebugLevel"))
+}
+
+func TestWatchFile(t *testing.T) {
+	c, path := newReloadTestConfig(t)
+
+	reloaded := make(chan *Delta, 1)
+	stop := c.watchFile(10*time.Millisecond, func(d *Delta) { reloaded <- d })
+	defer stop()
+
+	// Make sure the modification time changes on coarse file systems
+	time.Sleep(20 * time.Millisecond)
+	writeReloadTestConfig(t, path, "Sampling:\n  SampleRate: 42\nDebugLevel: info\nCollector: collector.example.com\n")
+	select {
+	case d := <-reloaded:
+		assert.True(t, d.Changed("Sampling.SampleRate"))
+	case <-time.After(5 * time.Second):
+		t.Fatal("the config file was not reloaded")
+	}
+	assert.Equal(t, 42, c.GetSampleRate())
+
+	stop()
+	stop()
+}
+
+func TestWatchFileOverride(t *testing.T) {
+	c, path := newReloadTestConfig(t)
+	override := filepath.Join(filepath.Dir(path), "override.json")
+	writeReloadTestConfig(t, override, `{"DebugLevel": "warn"}`)
+	t.Setenv(envSolarWindsAPMConfigOverrideFile, override)
+	c.Load()
+	require.Equal(t, "warn", c.GetDebugLevel())
+
+	reloaded := make(chan *Delta, 1)
+	stop := c.watchFile(10*time.Millisecond, func(d *Delta) { reloaded <- d })
+	defer stop()
+
+	time.Sleep(20 * time.Millisecond)
+	writeReloadTestConfig(t, override, `{"DebugLevel": "error"}`)
+	select {
+	case d := <-reloaded:
+		assert.True(t, d.Changed("DebugLevel"))
+	case <-time.After(5 * time.Second):
+		t.Fatal("the override file was not reloaded")
+	}
+	assert.Equal(t, "error", c.GetDebugLevel())
+	assert.Equal(t, 500000, c.GetSampleRate())
+}
+
+func TestWatchFileWithoutConfigFile(t *testing.T) {
+	ClearEnvs()
+	t.Chdir(t.TempDir())
+	c := NewConfig()
+	// No-op
+	c.watchFile(time.Millisecond, func(*Delta) { t.Fatal("unexpected reload") })()
+}
+
+func TestCheckModifiedMissingFile(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	oldLevel := log.Level()
+	log.SetLevel(log.INFO)
+	defer func() {
+		log.SetOutput(os.Stderr)
+		log.SetLevel(oldLevel)
+	}()
+
+	path := filepath.Join(t.TempDir(), "solarwinds-apm-goagent.yaml")
+	writeReloadTestConfig(t, path, "DebugLevel: info\n")
+	paths := []string{path}
+	last := make([]os.FileInfo, 1)
+	missing := make([]bool, 1)
+	assert.Equal(t, path, checkModified(paths, last, missing))
+
+	// Warned once while the file is missing
+	require.NoError(t, os.Remove(path))
+	for i := 0; i < 3; i++ {
+		assert.Empty(t, checkModified(paths, last, missing))
+	}
+	assert.Equal(t, 1, strings.Count(buf.String(), "Failed to check the config file"))
+
+	// And once more when it's back
+	writeReloadTestConfig(t, path, "DebugLevel: debug\n")
+	assert.Equal(t, path, checkModified(paths, last, missing))
+	assert.Contains(t, buf.String(), "The config file "+path+" is back")
+	require.NoError(t, os.Remove(path))
+	checkModified(paths, last, missing)
+	assert.Equal(t, 2, strings.Count(buf.String(), "Failed to check the config file"))
+}
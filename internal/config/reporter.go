@@ -38,19 +38,20 @@ type ReporterOptions struct {
 	// Ping interval in seconds
 	PingInterval int64 `yaml:"PingInterval,omitempty" default:"20"`
 
-	// Retry backoff initial delay
+	// Retry backoff initial delay in milliseconds
 	RetryDelayInitial int64 `yaml:"RetryDelayInitial,omitempty" default:"500"`
 
-	// Maximum retry delay
+	// Maximum retry delay in seconds
 	RetryDelayMax int `yaml:"RetryDelayMax,omitempty" default:"60"`
 
 	// Maximum redirect times
 	RedirectMax int `yaml:"RedirectMax,omitempty" default:"20"`
 
-	// The threshold of retries before debug printing
+	// The number of consecutive failures between two warnings, the other
+	// failures are only logged at the debug level
 	RetryLogThreshold int `yaml:"RetryLogThreshold,omitempty" default:"10"`
 
-	// The maximum retries
+	// The maximum retries before waiting for the next scheduled attempt
 	MaxRetries int `yaml:"MaxRetries,omitempty" default:"20"`
 }
 
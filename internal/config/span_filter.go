@@ -0,0 +1,103 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/solarwinds/apm-go/internal/log"
+)
+
+// SpanFilter drops the spans matching all of its conditions before export.
+// At least one condition must be set.
+type SpanFilter struct {
+	// The name of the instrumentation scope of the spans, where `*` matches
+	// any characters, e.g. `github.com/redis/*`
+	Scope string `yaml:"Scope,omitempty"`
+	// The regular expression matched against the span name
+	Name string `yaml:"Name,omitempty"`
+	// The span kinds: internal, server, client, producer or consumer
+	Kinds []string `yaml:"Kinds,omitempty"`
+	// The spans shorter than MinDuration are dropped, e.g. `500us`
+	MinDuration string `yaml:"MinDuration,omitempty"`
+}
+
+// ScopePattern returns the regular expression matching the Scope pattern, or
+// nil if Scope is not set
+func (f SpanFilter) ScopePattern() *regexp.Regexp {
+	if f.Scope == "" {
+		return nil
+	}
+	quoted := strings.ReplaceAll(regexp.QuoteMeta(f.Scope), `\*`, ".*")
+	return regexp.MustCompile("^" + quoted + "$")
+}
+
+// SpanFilter errors
+var (
+	ErrSFInvalidName        = errors.New("invalid Name")
+	ErrSFInvalidKind        = errors.New("invalid Kinds")
+	ErrSFInvalidMinDuration = errors.New("invalid MinDuration")
+	ErrSFEmpty              = errors.New("must set Scope, Name, Kinds or MinDuration")
+)
+
+var spanKinds = []string{"internal", "server", "client", "producer", "consumer"}
+
+func (f SpanFilter) validate() error {
+	if f.Scope == "" && f.Name == "" && len(f.Kinds) == 0 && f.MinDuration == "" {
+		return ErrSFEmpty
+	}
+	if _, err := regexp.Compile(f.Name); err != nil {
+		return ErrSFInvalidName
+	}
+	for _, k := range f.Kinds {
+		if !slices.Contains(spanKinds, k) {
+			return ErrSFInvalidKind
+		}
+	}
+	if f.MinDuration != "" {
+		if d, err := time.ParseDuration(f.MinDuration); err != nil || d <= 0 {
+			return ErrSFInvalidMinDuration
+		}
+	}
+	return nil
+}
+
+// validateSpanFilters drops the invalid span filters
+func validateSpanFilters(filters []SpanFilter) []SpanFilter {
+	return slices.DeleteFunc(filters, func(f SpanFilter) bool {
+		if err := f.validate(); err != nil {
+			log.Warningf("Ignore invalid span filter %+v: %s", f, err)
+			return true
+		}
+		return false
+	})
+}
+
+// cloneSpanFilters returns a deep copy so that callers can't modify the config
+func cloneSpanFilters(filters []SpanFilter) []SpanFilter {
+	if filters == nil {
+		return nil
+	}
+	res := make([]SpanFilter, len(filters))
+	for i, f := range filters {
+		res[i] = f
+		res[i].Kinds = slices.Clone(f.Kinds)
+	}
+	return res
+}
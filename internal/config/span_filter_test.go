@@ -0,0 +1,64 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpanFilterValidate(t *testing.T) {
+	for _, tc := range []struct {
+		filter SpanFilter
+		err    error
+	}{
+		{SpanFilter{Scope: "github.com/redis/*"}, nil},
+		{SpanFilter{Name: `^SELECT`, Kinds: []string{"client"}}, nil},
+		{SpanFilter{Kinds: []string{"internal"}, MinDuration: "500us"}, nil},
+		{SpanFilter{}, ErrSFEmpty},
+		{SpanFilter{Scope: "[odd"}, nil},
+		{SpanFilter{Name: "(invalid"}, ErrSFInvalidName},
+		{SpanFilter{Kinds: []string{"server", "Client"}}, ErrSFInvalidKind},
+		{SpanFilter{MinDuration: "1"}, ErrSFInvalidMinDuration},
+		{SpanFilter{MinDuration: "-1ms"}, ErrSFInvalidMinDuration},
+	} {
+		assert.Equal(t, tc.err, tc.filter.validate(), "%+v", tc.filter)
+	}
+}
+
+func TestSpanFilterScopePattern(t *testing.T) {
+	assert.Nil(t, SpanFilter{Name: "foo"}.ScopePattern())
+	re := SpanFilter{Scope: "github.com/redis/*"}.ScopePattern()
+	assert.True(t, re.MatchString("github.com/redis/go-redis/extra/redisotel"))
+	assert.False(t, re.MatchString("github.com/redis"))
+	re = SpanFilter{Scope: "go.opentelemetry.io/otel/sql"}.ScopePattern()
+	assert.True(t, re.MatchString("go.opentelemetry.io/otel/sql"))
+	assert.False(t, re.MatchString("go.opentelemetry.io/otel/sqlx"))
+}
+
+func TestWithSpanFilters(t *testing.T) {
+	ClearEnvs()
+	c := NewConfig(WithSpanFilters([]SpanFilter{
+		{Kinds: []string{"internal"}, MinDuration: "1ms"},
+		{Name: "("},
+	}))
+	filters := c.GetSpanFilters()
+	assert.Equal(t, []SpanFilter{{Kinds: []string{"internal"}, MinDuration: "1ms"}}, filters)
+
+	// The returned filters are a copy
+	filters[0].Kinds[0] = "server"
+	assert.Equal(t, "internal", c.GetSpanFilters()[0].Kinds[0])
+}
@@ -0,0 +1,52 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"strconv"
+
+	"github.com/solarwinds/apm-go/internal/log"
+)
+
+// TailSamplingConfig defines the buffering of the traces which are not
+// sampled. When enabled, their spans are recorded and held in memory until the
+// local trace completes, then exported if one of its entry spans ended with an
+// error or took longer than LatencyThreshold.
+type TailSamplingConfig struct {
+	Enabled bool `yaml:"Enabled,omitempty" env:"SW_APM_TAIL_SAMPLING" default:"false"`
+	// The duration in milliseconds above which the entry spans are promoted,
+	// only errors are promoted if it's 0
+	LatencyThreshold int `yaml:"LatencyThreshold,omitempty" env:"SW_APM_TAIL_SAMPLING_LATENCY_THRESHOLD"`
+	// The maximum number of traces held in memory
+	MaxTraces int `yaml:"MaxTraces,omitempty" env:"SW_APM_TAIL_SAMPLING_MAX_TRACES" default:"1000"`
+	// The maximum number of spans held in memory per trace
+	MaxSpansPerTrace int `yaml:"MaxSpansPerTrace,omitempty" env:"SW_APM_TAIL_SAMPLING_MAX_SPANS_PER_TRACE" default:"500"`
+}
+
+// validate resets the invalid threshold and limits
+func (t *TailSamplingConfig) validate() {
+	if t.LatencyThreshold < 0 {
+		log.Warning(InvalidEnv("TailSampling.LatencyThreshold", strconv.Itoa(t.LatencyThreshold)))
+		t.LatencyThreshold = 0
+	}
+	if t.MaxTraces <= 0 {
+		log.Warning(InvalidEnv("TailSampling.MaxTraces", strconv.Itoa(t.MaxTraces)))
+		t.MaxTraces, _ = strconv.Atoi(getFieldDefaultValue(t, "MaxTraces"))
+	}
+	if t.MaxSpansPerTrace <= 0 {
+		log.Warning(InvalidEnv("TailSampling.MaxSpansPerTrace", strconv.Itoa(t.MaxSpansPerTrace)))
+		t.MaxSpansPerTrace, _ = strconv.Atoi(getFieldDefaultValue(t, "MaxSpansPerTrace"))
+	}
+}
@@ -0,0 +1,98 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/solarwinds/apm-go/internal/log"
+)
+
+// TransactionNamingConfig defines how the transaction names are derived from
+// the URL paths of the requests without an `http.route`.
+type TransactionNamingConfig struct {
+	// The naming rules, the first one matching the URL path is applied. The
+	// first two path segments are used if none matches.
+	Rules []TransactionNamingRule `yaml:"Rules,omitempty"`
+	// The maximum number of distinct transaction names derived from the URL
+	// paths. Once it's reached, the requests with a new path are named `other`.
+	// It's unlimited if 0. The default matches TransactionMetricsLimit, so
+	// that the names derived from the paths alone don't overflow the limit of
+	// the names per metrics export, both limits naming the overflow `other`.
+	MaxNames int `yaml:"MaxNames,omitempty" env:"SW_APM_TRANSACTION_NAME_LIMIT" default:"200"`
+}
+
+// TransactionNamingRule derives the transaction name of the URL paths matching
+// RegEx. The name is either the Name template or the first Segments path
+// segments, or the whole path if neither is set.
+type TransactionNamingRule struct {
+	// The regular expression matched against the URL path, every path matches
+	// if it's empty.
+	RegEx string `yaml:"RegEx,omitempty"`
+	// The transaction name, which may refer to the capture groups of RegEx,
+	// e.g. `/api/${1}`
+	Name string `yaml:"Name,omitempty"`
+	// The number of path segments kept in the transaction name
+	Segments int `yaml:"Segments,omitempty"`
+	// Whether the ID segments, i.e. numbers, UUIDs and long hexadecimal
+	// strings, are replaced with `:id`
+	NormalizeIDs bool `yaml:"NormalizeIDs,omitempty"`
+}
+
+// TransactionNamingRule errors
+var (
+	ErrTNInvalidRegEx    = errors.New("invalid RegEx")
+	ErrTNInvalidSegments = errors.New("invalid Segments")
+	ErrTNEmptyRule       = errors.New("must set Name, Segments or NormalizeIDs")
+)
+
+func (r TransactionNamingRule) validate() error {
+	if _, err := regexp.Compile(r.RegEx); err != nil {
+		return ErrTNInvalidRegEx
+	}
+	if r.Segments < 0 {
+		return ErrTNInvalidSegments
+	}
+	if r.Name == "" && r.Segments == 0 && !r.NormalizeIDs {
+		return ErrTNEmptyRule
+	}
+	return nil
+}
+
+// validate drops the invalid rules and resets an invalid MaxNames
+func (t *TransactionNamingConfig) validate() {
+	t.Rules = slices.DeleteFunc(t.Rules, func(r TransactionNamingRule) bool {
+		if err := r.validate(); err != nil {
+			log.Warningf("Ignore invalid transaction naming rule %+v: %s", r, err)
+			return true
+		}
+		return false
+	})
+	if t.MaxNames < 0 {
+		log.Warning(InvalidEnv("TransactionNaming.MaxNames", strconv.Itoa(t.MaxNames)))
+		t.MaxNames, _ = strconv.Atoi(getFieldDefaultValue(t, "MaxNames"))
+	}
+}
+
+// clone returns a deep copy so that callers can't modify the config
+func (t TransactionNamingConfig) clone() TransactionNamingConfig {
+	return TransactionNamingConfig{
+		Rules:    slices.Clone(t.Rules),
+		MaxNames: t.MaxNames,
+	}
+}
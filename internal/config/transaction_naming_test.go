@@ -0,0 +1,67 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionNamingRuleValidate(t *testing.T) {
+	assert.NoError(t, TransactionNamingRule{RegEx: `^/api/(\w+)`, Name: "/api/$1"}.validate())
+	assert.NoError(t, TransactionNamingRule{Segments: 3}.validate())
+	assert.NoError(t, TransactionNamingRule{NormalizeIDs: true}.validate())
+	assert.ErrorIs(t, TransactionNamingRule{RegEx: `(`, Name: "x"}.validate(), ErrTNInvalidRegEx)
+	assert.ErrorIs(t, TransactionNamingRule{Segments: -1}.validate(), ErrTNInvalidSegments)
+	assert.ErrorIs(t, TransactionNamingRule{RegEx: `^/api`}.validate(), ErrTNEmptyRule)
+}
+
+func TestTransactionNamingConfig(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "*-config.yaml")
+	require.NoError(t, err)
+	_, err = f.WriteString(`
+TransactionNaming:
+  MaxNames: -1
+  Rules:
+    - RegEx: ^/api/v(\d+)/(\w+)
+      Name: /api/v${1}/${2}
+    - RegEx: (
+      Name: invalid
+    - Segments: 3
+      NormalizeIDs: true
+`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	ClearEnvs()
+	t.Setenv(envSolarWindsAPMConfigFile, f.Name())
+	c := NewConfig()
+	assert.Equal(t, TransactionNamingConfig{
+		Rules: []TransactionNamingRule{
+			{RegEx: `^/api/v(\d+)/(\w+)`, Name: "/api/v${1}/${2}"},
+			{Segments: 3, NormalizeIDs: true},
+		},
+		MaxNames: 200,
+	}, c.GetTransactionNaming())
+
+	t.Setenv("SW_APM_TRANSACTION_NAME_LIMIT", "100")
+	assert.Equal(t, 100, NewConfig().GetTransactionNaming().MaxNames)
+	// Unlimited
+	t.Setenv("SW_APM_TRANSACTION_NAME_LIMIT", "0")
+	assert.Equal(t, 0, NewConfig().GetTransactionNaming().MaxNames)
+}
@@ -24,6 +24,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/solarwinds/apm-go/internal/log"
+	"github.com/solarwinds/apm-go/internal/sqlsanitizer"
 )
 
 // InvalidEnv returns a string indicating invalid environment variables
@@ -118,6 +119,27 @@ func IsValidSampleRate(rate int) bool {
 	return rate >= MinSampleRate && rate <= MaxSampleRate
 }
 
+// IsValidPrecision checks if the histogram precision is valid
+func IsValidPrecision(p int) bool {
+	return p >= 0 && p <= MaxPrecision
+}
+
+// IsValidHistogramUnit checks if the histogram unit is valid
+func IsValidHistogramUnit(u string) bool {
+	return u == HistogramUnitMilliseconds || u == HistogramUnitMicroseconds
+}
+
+// IsValidHistogramAggregation checks if the histogram aggregation is valid. It
+// may be empty, in which case the default aggregation of the exporter is used.
+func IsValidHistogramAggregation(a string) bool {
+	return a == "" || a == HistogramAggregationExplicit || a == HistogramAggregationExponential
+}
+
+// IsValidSQLSanitize checks if the SQL sanitization level is valid
+func IsValidSQLSanitize(level int) bool {
+	return sqlsanitizer.IsValidLevel(level)
+}
+
 func IsValidTokenBucketRate(rate float64) bool {
 	return rate >= 0 && rate <= maxTokenBucketRate
 }
@@ -79,6 +79,33 @@ func TestIsValidTracingMode(t *testing.T) {
 	assert.Equal(t, false, IsValidTracingMode("NEVER"))
 }
 
+func TestIsValidSQLSanitize(t *testing.T) {
+	for _, level := range []int{0, 1, 2, 4} {
+		assert.True(t, IsValidSQLSanitize(level), level)
+	}
+	for _, level := range []int{-1, 3, 5, 8} {
+		assert.False(t, IsValidSQLSanitize(level), level)
+	}
+}
+
+func TestIsValidHistogramOptions(t *testing.T) {
+	assert.True(t, IsValidPrecision(0))
+	assert.True(t, IsValidPrecision(5))
+	assert.True(t, IsValidPrecision(MaxPrecision))
+	assert.False(t, IsValidPrecision(-1))
+	assert.False(t, IsValidPrecision(MaxPrecision+1))
+
+	assert.True(t, IsValidHistogramUnit("ms"))
+	assert.True(t, IsValidHistogramUnit("us"))
+	assert.False(t, IsValidHistogramUnit("s"))
+	assert.False(t, IsValidHistogramUnit(""))
+
+	assert.True(t, IsValidHistogramAggregation(""))
+	assert.True(t, IsValidHistogramAggregation("explicit"))
+	assert.True(t, IsValidHistogramAggregation("exponential"))
+	assert.False(t, IsValidHistogramAggregation("summary"))
+}
+
 func TestConverters(t *testing.T) {
 	assert.Equal(t, DisabledTracingMode, NormalizeTracingMode("disabled"))
 	assert.Equal(t, DisabledTracingMode, NormalizeTracingMode("never"))
@@ -15,10 +15,22 @@
 package config
 
 import (
+	"io"
+	"os"
+
 	"github.com/solarwinds/apm-go/internal/log"
 )
 
-var conf = NewConfig()
+// conf is loaded quietly at init, so that the programs which don't start the
+// library, e.g. swo-config, don't print its problems. They're logged once it
+// starts, see LoadAndLog.
+var conf = loadQuietly()
+
+func loadQuietly() *Config {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+	return NewConfig()
+}
 
 // GetCollector is a wrapper to the method of the global config
 var GetCollector = conf.GetCollector
@@ -26,6 +38,15 @@ var GetCollector = conf.GetCollector
 // SettingsURL is a wrapper to the method of the global config
 var SettingsURL = conf.GetSettingsURL
 
+// GetSettingsCacheFile is a wrapper to the method of the global config
+var GetSettingsCacheFile = conf.GetSettingsCacheFile
+
+// GetSettingsStartupJitter is a wrapper to the method of the global config
+var GetSettingsStartupJitter = conf.GetSettingsStartupJitter
+
+// GetSettingsUpdateJitter is a wrapper to the method of the global config
+var GetSettingsUpdateJitter = conf.GetSettingsUpdateJitter
+
 // GetServiceKey is a wrapper to the method of the global config
 var GetServiceKey = conf.GetServiceKey
 
@@ -44,12 +65,21 @@ var SamplingConfigured = conf.SamplingConfigured
 // GetPrependDomain is a wrapper to the method of the global config
 var GetPrependDomain = conf.GetPrependDomain
 
+// GetTransactionMetricsLimit is a wrapper to the method of the global config
+var GetTransactionMetricsLimit = conf.GetTransactionMetricsLimit
+
 // GetHostAlias is a wrapper to the method of the global config
 var GetHostAlias = conf.GetHostAlias
 
 // GetPrecision is a wrapper to the method of the global config
 var GetPrecision = conf.GetPrecision
 
+// GetHistogramUnit is a wrapper to the method of the global config
+var GetHistogramUnit = conf.GetHistogramUnit
+
+// GetHistogramAggregation is a wrapper to the method of the global config
+var GetHistogramAggregation = conf.GetHistogramAggregation
+
 // GetEnabled is a wrapper to the method of the global config
 var GetEnabled = conf.GetEnabled
 
@@ -62,6 +92,15 @@ var GetEc2MetadataTimeout = conf.GetEc2MetadataTimeout
 // DebugLevel is a wrapper to the method of the global config
 var DebugLevel = conf.GetDebugLevel
 
+// LogFormat is a wrapper to the method of the global config
+var LogFormat = conf.GetLogFormat
+
+// LogRateLimitInterval is a wrapper to the method of the global config
+var LogRateLimitInterval = conf.GetLogRateLimitInterval
+
+// GetConfigReloadInterval is a wrapper to the method of the global config
+var GetConfigReloadInterval = conf.GetConfigReloadInterval
+
 // GetTriggerTrace is a wrapper to the method of the global config
 var GetTriggerTrace = conf.GetTriggerTrace
 
@@ -83,6 +122,18 @@ var GetTransactionFiltering = conf.GetTransactionFiltering
 
 var GetTransactionName = conf.GetTransactionName
 
+// GetTransactionNaming is a wrapper to the method of the global config
+var GetTransactionNaming = conf.GetTransactionNaming
+
+// GetRedaction is a wrapper to the method of the global config
+var GetRedaction = conf.GetRedaction
+
+// GetSpanFilters is a wrapper to the method of the global config
+var GetSpanFilters = conf.GetSpanFilters
+
+// GetTailSampling is a wrapper to the method of the global config
+var GetTailSampling = conf.GetTailSampling
+
 // GetSQLSanitize is a wrapper to method GetSQLSanitize of the global variable config.
 var GetSQLSanitize = conf.GetSQLSanitize
 
@@ -97,7 +148,14 @@ var Load = conf.Load
 
 var GetDelta = conf.GetDelta
 
-func init() {
+// Reload is a wrapper to the method of the global config
+var Reload = conf.Reload
+
+// LoadAndLog loads the configuration as Load does, logging its problems and
+// the settings which aren't the default ones. It's called when the library
+// starts.
+func LoadAndLog(opts ...Option) {
+	conf.Load(opts...)
 	if conf.GetEnabled() {
 		log.Warningf("Accepted config items: \n%s", conf.GetDelta())
 	}
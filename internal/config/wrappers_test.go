@@ -14,9 +14,11 @@
 package config
 
 import (
+	"io"
 	"os"
 	"testing"
 
+	"github.com/solarwinds/apm-go/internal/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -32,3 +34,23 @@ func TestWrappers(t *testing.T) {
 
 	assert.NotEqual(t, nil, ReporterOpts())
 }
+
+func TestLoadQuietly(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stderr := os.Stderr
+	os.Stderr = w
+	t.Cleanup(func() {
+		os.Stderr = stderr
+		log.SetOutput(stderr)
+	})
+	log.SetOutput(w)
+	t.Setenv(envSolarWindsAPMHistogramPrecision, "-1")
+
+	c := loadQuietly()
+	assert.Equal(t, ToInteger(getFieldDefaultValue(&Config{}, "Precision")), c.GetPrecision())
+	require.NoError(t, w.Close())
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Empty(t, string(out))
+}
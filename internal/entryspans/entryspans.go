@@ -41,6 +41,8 @@ type manager interface {
 	delete(tid trace.TraceID, sid trace.SpanID) error
 	current(tid trace.TraceID) (*entrySpan, bool)
 	setTransactionName(tid trace.TraceID, name string) error
+	count() (traces int, spans int)
+	traceCount(tid trace.TraceID) int
 }
 
 type entrySpan struct {
@@ -71,6 +73,14 @@ func (n noopManager) setTransactionName(trace.TraceID, string) error {
 	return CannotSetTransactionName
 }
 
+func (n noopManager) count() (int, int) {
+	return 0, 0
+}
+
+func (n noopManager) traceCount(trace.TraceID) int {
+	return 0
+}
+
 var (
 	_ manager = &stdManager{}
 	_ manager = &noopManager{}
@@ -157,6 +167,14 @@ func Current(tid trace.TraceID) (trace.SpanID, bool) {
 	return curr.spanId, ok
 }
 
+// CurrentSpan returns the current entry span of the trace
+func CurrentSpan(tid trace.TraceID) (sdktrace.ReadOnlySpan, bool) {
+	if curr, ok := state.current(tid); ok && curr.spanHandle != nil {
+		return curr.spanHandle, true
+	}
+	return nil, false
+}
+
 func (e *stdManager) setTransactionName(tid trace.TraceID, name string) error {
 	e.mut.Lock()
 	defer e.mut.Unlock()
@@ -183,6 +201,34 @@ func GetTransactionName(tid trace.TraceID) string {
 	return ""
 }
 
+func (e *stdManager) count() (int, int) {
+	e.mut.RLock()
+	defer e.mut.RUnlock()
+	spans := 0
+	for _, list := range e.spans {
+		spans += len(list)
+	}
+	return len(e.spans), spans
+}
+
+// Count returns the number of traces with active entry spans and the total
+// number of active entry spans.
+func Count() (traces int, spans int) {
+	return state.count()
+}
+
+func (e *stdManager) traceCount(tid trace.TraceID) int {
+	e.mut.RLock()
+	defer e.mut.RUnlock()
+	return len(e.spans[tid])
+}
+
+// TraceCount returns the number of active entry spans of the trace. It's
+// always 0 when the entry spans are not tracked, e.g. in Lambda.
+func TraceCount(tid trace.TraceID) int {
+	return state.traceCount(tid)
+}
+
 func IsEntrySpan(span sdktrace.ReadOnlySpan) bool {
 	parent := span.Parent()
 	return !parent.IsValid() || parent.IsRemote()
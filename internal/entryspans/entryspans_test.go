@@ -241,3 +241,30 @@ func TestDelete(t *testing.T) {
 	_, ok := state.spans[s.SpanContext().TraceID()]
 	require.False(t, ok)
 }
+
+func TestCount(t *testing.T) {
+	state := state.(*stdManager)
+	state.reset()
+	t.Cleanup(state.reset)
+	tr, teardown := testutils.TracerSetup()
+	defer teardown()
+
+	traces, spans := Count()
+	require.Equal(t, 0, traces)
+	require.Equal(t, 0, spans)
+
+	ctx := context.Background()
+	_, span1 := tr.Start(ctx, "A")
+	_, span2 := tr.Start(ctx, "B")
+	_, span3 := tr.Start(ctx, "C")
+	state.push(traceA, span1.(sdktrace.ReadWriteSpan))
+	state.push(traceA, span2.(sdktrace.ReadWriteSpan))
+	state.push(traceB, span3.(sdktrace.ReadWriteSpan))
+
+	traces, spans = Count()
+	require.Equal(t, 2, traces)
+	require.Equal(t, 3, spans)
+	require.Equal(t, 2, TraceCount(traceA))
+	require.Equal(t, 1, TraceCount(traceB))
+	require.Equal(t, 0, TraceCount(trace.TraceID{0xff}))
+}
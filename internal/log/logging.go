@@ -49,7 +49,8 @@ const (
 )
 
 const (
-	envSolarWindsAPMLogLevel = "SW_APM_DEBUG_LEVEL"
+	envSolarWindsAPMLogLevel  = "SW_APM_DEBUG_LEVEL"
+	envSolarWindsAPMLogFormat = "SW_APM_LOG_FORMAT"
 )
 
 // LevelStr represents the log levels in strings
@@ -71,6 +72,7 @@ var (
 
 func init() {
 	SetLevelFromStr(os.Getenv(envSolarWindsAPMLogLevel))
+	SetFormatFromStr(os.Getenv(envSolarWindsAPMLogFormat))
 }
 
 // SetOutput sets the output destination for the internal logger.
@@ -163,24 +165,34 @@ func shouldLog(lv LogLevel) bool {
 }
 
 // logIt prints logs based on the debug level.
-func logIt(level LogLevel, msg string, args []interface{}) {
+func logIt(level LogLevel, component string, msg string, args []interface{}) {
 	if !shouldLog(level) {
 		return
 	}
+	output(level, component, formatMessage(msg, args), args)
+}
+
+// output prints the formatted message s. It must only be called by logIt and
+// logLimited, so that the callers of their wrappers are reported.
+func output(level LogLevel, component string, s string, args []interface{}) {
+	if h := currentHandler(); h != nil {
+		handleRecord(h, level, component, s, args)
+		return
+	}
 
 	var buffer bytes.Buffer
-	// layer 1: logIt(), layer 2: its wrappers, e.g., Info()
-	const numberOfLayersToSkip = 2
+	// layer 1: output(), layer 2: logIt(), layer 3: its wrappers, e.g., Info()
+	const numberOfLayersToSkip = 3
 
 	var pre string
 	if level == DEBUG {
 		// `runtime.Caller()` is called here to get the metadata of the caller of `Caller`:
 		// the program counter, file name, and line number within the file of the corresponding call.
 		// The argument `skip` is the number of stack frames to skip (for example, if skip == 0
-		// you will always get the metadata of `logIt`, which is useless.)
-		// skip = 2 is used here as there are wrappers on top of `logIt` (Info,
-		// Infof, Error, etc). By skipping two layers (logIt and its wrapper), you may get
-		// the information of real callers of the logging functions.
+		// you will always get the metadata of `output`, which is useless.)
+		// skip = 3 is used here as there are wrappers on top of `logIt` (Info,
+		// Infof, Error, etc). By skipping three layers (output, logIt and its wrapper), you
+		// may get the information of real callers of the logging functions.
 		_, file, line, ok := runtime.Caller(numberOfLayersToSkip)
 		if ok {
 			pre = fmt.Sprintf("%-5s [solarwinds_apm] %s:%d ", LevelStr[level], filepath.Base(file), line)
@@ -193,68 +205,69 @@ func logIt(level LogLevel, msg string, args []interface{}) {
 
 	buffer.WriteString(pre)
 
-	var s string
-	if msg == "" {
-		s = fmt.Sprint(args...)
-	} else {
-		s = fmt.Sprintf(msg, args...)
-	}
 	buffer.WriteString(s)
 
 	logger.Print(buffer.String())
 }
 
+func formatMessage(msg string, args []interface{}) string {
+	if msg == "" {
+		return fmt.Sprint(args...)
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
 // Logf formats the log message with specified args
 // and print it in the specified level
 func Logf(level LogLevel, msg string, args ...interface{}) {
-	logIt(level, msg, args)
+	logIt(level, "", msg, args)
 }
 
 // Log prints the log message in the specified level
 func Log(level LogLevel, args ...interface{}) {
-	logIt(level, "", args)
+	logIt(level, "", "", args)
 }
 
 // Debugf formats the log message with specified args
 // and print it in the specified level
 func Debugf(msg string, args ...interface{}) {
-	logIt(DEBUG, msg, args)
+	logIt(DEBUG, "", msg, args)
 }
 
 // Debug prints the log message in the specified level
 func Debug(args ...interface{}) {
-	logIt(DEBUG, "", args)
+	logIt(DEBUG, "", "", args)
 }
 
 // Infof formats the log message with specified args
 // and print it in the specified level
 func Infof(msg string, args ...interface{}) {
-	logIt(INFO, msg, args)
+	logIt(INFO, "", msg, args)
 }
 
 // Info prints the log message in the specified level
 func Info(args ...interface{}) {
-	logIt(INFO, "", args)
+	logIt(INFO, "", "", args)
 }
 
 // Warningf formats the log message with specified args
 // and print it in the specified level
 func Warningf(msg string, args ...interface{}) {
-	logIt(WARNING, msg, args)
+	logIt(WARNING, "", msg, args)
 }
 
 // Warning prints the log message in the specified level
 func Warning(args ...interface{}) {
-	logIt(WARNING, "", args)
+	logIt(WARNING, "", "", args)
 }
 
 // Errorf formats the log message with specified args
 // and print it in the specified level
 func Errorf(msg string, args ...interface{}) {
-	logIt(ERROR, msg, args)
+	logIt(ERROR, "", msg, args)
 }
 
 // Error prints the log message in the specified level
 func Error(args ...interface{}) {
-	logIt(ERROR, "", args)
+	logIt(ERROR, "", "", args)
 }
@@ -0,0 +1,191 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRateLimitInterval is the default interval during which a rate
+// limited message is printed at most once
+const DefaultRateLimitInterval = 10 * time.Minute
+
+// maxRateLimitKeys bounds the number of messages tracked by the rate limiter.
+// The messages are printed without limit once it's reached.
+const maxRateLimitKeys = 1000
+
+var limiter = &rateLimiter{
+	interval: DefaultRateLimitInterval,
+	entries:  map[string]*rateLimitEntry{},
+}
+
+// rateLimiter suppresses the repeated messages of the same key
+type rateLimiter struct {
+	sync.Mutex
+	interval time.Duration
+	entries  map[string]*rateLimitEntry
+}
+
+type rateLimitEntry struct {
+	// when the message was last printed
+	printed time.Time
+	// the number of messages suppressed since then
+	suppressed int
+}
+
+// SetRateLimitInterval sets the interval during which a rate limited message
+// is printed at most once. The rate limiting is disabled if it's 0. The
+// messages already printed are kept, so that they stay limited when the
+// interval is reloaded.
+func SetRateLimitInterval(interval time.Duration) {
+	limiter.Lock()
+	defer limiter.Unlock()
+	limiter.interval = interval
+}
+
+// allow returns whether the message of key is printed at now and, if so, the
+// number of messages suppressed since it was last printed and how long ago it
+// was.
+func (r *rateLimiter) allow(key string, now time.Time) (bool, int, time.Duration) {
+	r.Lock()
+	defer r.Unlock()
+	if r.interval <= 0 {
+		return true, 0, 0
+	}
+	e, ok := r.entries[key]
+	if !ok {
+		if len(r.entries) >= maxRateLimitKeys {
+			r.evict(now)
+		}
+		if len(r.entries) < maxRateLimitKeys {
+			r.entries[key] = &rateLimitEntry{printed: now}
+		}
+		return true, 0, 0
+	}
+	elapsed := now.Sub(e.printed)
+	if elapsed < r.interval {
+		e.suppressed++
+		return false, 0, 0
+	}
+	suppressed := e.suppressed
+	e.printed, e.suppressed = now, 0
+	return true, suppressed, elapsed
+}
+
+// evict removes the entries whose interval has elapsed
+func (r *rateLimiter) evict(now time.Time) {
+	for key, e := range r.entries {
+		if now.Sub(e.printed) >= r.interval {
+			delete(r.entries, key)
+		}
+	}
+}
+
+// logLimited prints the message of key unless it was printed during the
+// rate limit interval, in which case it's counted and the count is appended
+// to the next message printed.
+func logLimited(level LogLevel, component string, key string, msg string, args []interface{}) {
+	if !shouldLog(level) {
+		return
+	}
+	ok, suppressed, elapsed := limiter.allow(component+"/"+key, time.Now())
+	if !ok {
+		return
+	}
+	s := formatMessage(msg, args)
+	if suppressed > 0 {
+		times := "times"
+		if suppressed == 1 {
+			times = "time"
+		}
+		s = fmt.Sprintf("%s (repeated %d %s in last %s)", s, suppressed, times, formatDuration(elapsed))
+	}
+	output(level, component, s, args)
+}
+
+// formatDuration formats d rounded to the second, without the trailing zero
+// units, e.g. `10m` rather than `10m0s`
+func formatDuration(d time.Duration) string {
+	s := d.Round(time.Second).String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// LimitedLogger prints the messages of a key at most once per rate limit
+// interval, see SetRateLimitInterval. The messages suppressed are counted,
+// and reported by the next message printed, e.g. `(repeated 9 times in last
+// 10m)`.
+type LimitedLogger struct {
+	component string
+	key       string
+}
+
+// Limited returns a LimitedLogger for the messages of key, e.g. the message
+// format. The keys are scoped by the component of the logger.
+func (l *Logger) Limited(key string) LimitedLogger {
+	return LimitedLogger{component: l.component, key: key}
+}
+
+// Debugf formats the log message with specified args
+// and print it in the specified level
+func (l LimitedLogger) Debugf(msg string, args ...interface{}) {
+	logLimited(DEBUG, l.component, l.key, msg, args)
+}
+
+// Debug prints the log message in the specified level
+func (l LimitedLogger) Debug(args ...interface{}) {
+	logLimited(DEBUG, l.component, l.key, "", args)
+}
+
+// Infof formats the log message with specified args
+// and print it in the specified level
+func (l LimitedLogger) Infof(msg string, args ...interface{}) {
+	logLimited(INFO, l.component, l.key, msg, args)
+}
+
+// Info prints the log message in the specified level
+func (l LimitedLogger) Info(args ...interface{}) {
+	logLimited(INFO, l.component, l.key, "", args)
+}
+
+// Warningf formats the log message with specified args
+// and print it in the specified level
+func (l LimitedLogger) Warningf(msg string, args ...interface{}) {
+	logLimited(WARNING, l.component, l.key, msg, args)
+}
+
+// Warning prints the log message in the specified level
+func (l LimitedLogger) Warning(args ...interface{}) {
+	logLimited(WARNING, l.component, l.key, "", args)
+}
+
+// Errorf formats the log message with specified args
+// and print it in the specified level
+func (l LimitedLogger) Errorf(msg string, args ...interface{}) {
+	logLimited(ERROR, l.component, l.key, msg, args)
+}
+
+// Error prints the log message in the specified level
+func (l LimitedLogger) Error(args ...interface{}) {
+	logLimited(ERROR, l.component, l.key, "", args)
+}
@@ -0,0 +1,170 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	r := &rateLimiter{interval: time.Minute, entries: map[string]*rateLimitEntry{}}
+	now := time.Now()
+
+	ok, suppressed, _ := r.allow("a", now)
+	assert.True(t, ok)
+	assert.Zero(t, suppressed)
+	for i := 1; i <= 3; i++ {
+		ok, _, _ = r.allow("a", now.Add(time.Duration(i)*time.Second))
+		assert.False(t, ok)
+	}
+	// The keys are limited separately
+	ok, _, _ = r.allow("b", now.Add(time.Second))
+	assert.True(t, ok)
+
+	ok, suppressed, elapsed := r.allow("a", now.Add(time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, 3, suppressed)
+	assert.Equal(t, time.Minute, elapsed)
+	ok, _, _ = r.allow("a", now.Add(time.Minute+time.Second))
+	assert.False(t, ok)
+
+	// Disabled
+	r.interval = 0
+	for i := 0; i < 3; i++ {
+		ok, suppressed, _ = r.allow("a", now)
+		assert.True(t, ok)
+		assert.Zero(t, suppressed)
+	}
+}
+
+func TestRateLimiterMaxKeys(t *testing.T) {
+	r := &rateLimiter{interval: time.Minute, entries: map[string]*rateLimitEntry{}}
+	now := time.Now()
+	for i := 0; i < maxRateLimitKeys; i++ {
+		ok, _, _ := r.allow(fmt.Sprint(i), now)
+		require.True(t, ok)
+	}
+	// Not limited once full
+	for i := 0; i < 2; i++ {
+		ok, _, _ := r.allow("extra", now)
+		assert.True(t, ok)
+	}
+	assert.Len(t, r.entries, maxRateLimitKeys)
+
+	// The expired entries are evicted
+	ok, _, _ := r.allow("extra", now.Add(time.Minute))
+	assert.True(t, ok)
+	assert.Len(t, r.entries, 1)
+	ok, _, _ = r.allow("extra", now.Add(time.Minute))
+	assert.False(t, ok)
+}
+
+func TestFormatDuration(t *testing.T) {
+	for d, expected := range map[time.Duration]string{
+		10 * time.Minute:                       "10m",
+		10*time.Minute + 1500*time.Millisecond: "10m2s",
+		time.Hour:                              "1h",
+		time.Hour + time.Minute:                "1h1m",
+		45 * time.Second:                       "45s",
+	} {
+		assert.Equal(t, expected, formatDuration(d))
+	}
+}
+
+func TestLimited(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	oldLevel := Level()
+	SetLevel(DEBUG)
+	SetRateLimitInterval(time.Hour)
+	limiter.Lock()
+	limiter.entries = map[string]*rateLimitEntry{}
+	limiter.Unlock()
+	defer func() {
+		SetOutput(os.Stderr)
+		SetLevel(oldLevel)
+		SetRateLimitInterval(DefaultRateLimitInterval)
+	}()
+
+	logger := WithComponent("settings")
+	for i := 0; i < 5; i++ {
+		logger.Limited("failed").Warningf("failed to retrieve the settings: attempt %d", i)
+	}
+	assert.Equal(t, 1, strings.Count(buf.String(), "failed to retrieve the settings"))
+	assert.Contains(t, buf.String(), "attempt 0")
+	// Still limited once the interval is set again, e.g. on reload
+	SetRateLimitInterval(time.Hour)
+	logger.Limited("failed").Warning("failed to retrieve the settings")
+	assert.Equal(t, 1, strings.Count(buf.String(), "failed to retrieve the settings"))
+	// Scoped by the component
+	WithComponent("sampler").Limited("failed").Warning("sampler failed")
+	assert.Contains(t, buf.String(), "sampler failed")
+
+	// Report the suppressed messages once the interval elapsed
+	buf.Reset()
+	limiter.Lock()
+	limiter.entries["settings/failed"].printed = time.Now().Add(-time.Hour)
+	limiter.Unlock()
+	logger.Limited("failed").Warning("failed to retrieve the settings")
+	assert.True(t, strings.HasSuffix(buf.String(), "failed to retrieve the settings (repeated 5 times in last 1h)\n"), buf.String())
+
+	// The log level applies first
+	buf.Reset()
+	SetLevel(ERROR)
+	logger.Limited("filtered").Info("filtered by the log level")
+	assert.Empty(t, buf.String())
+	limiter.Lock()
+	assert.NotContains(t, limiter.entries, "settings/filtered")
+	limiter.Unlock()
+
+	// Disabled
+	SetLevel(DEBUG)
+	SetRateLimitInterval(0)
+	for i := 0; i < 3; i++ {
+		logger.Limited("failed").Debug("not limited")
+	}
+	assert.Equal(t, 3, strings.Count(buf.String(), "not limited"))
+	// The source is the caller of the logger
+	assert.Contains(t, buf.String(), "ratelimit_test.go")
+}
+
+func TestLimitedHandler(t *testing.T) {
+	oldLevel := Level()
+	SetLevel(DEBUG)
+	h := &recordingHandler{level: slog.LevelDebug}
+	SetHandler(h)
+	defer func() {
+		SetHandler(nil)
+		SetLevel(oldLevel)
+		SetRateLimitInterval(DefaultRateLimitInterval)
+	}()
+
+	WithComponent("exporter").Limited("export").Errorf("export failed")
+	require.Len(t, h.records, 1)
+	assert.Equal(t, "export failed", h.records[0].Message)
+	// The source is the caller of the logger
+	frame, _ := runtime.CallersFrames([]uintptr{h.records[0].PC}).Next()
+	assert.Equal(t, "github.com/solarwinds/apm-go/internal/log.TestLimitedHandler", frame.Function)
+}
@@ -0,0 +1,190 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Format is the output format of the internal logger
+type Format string
+
+const (
+	// TextFormat prints the logs as plain text lines
+	TextFormat Format = "text"
+	// JSONFormat prints the logs as JSON objects, one per line
+	JSONFormat Format = "json"
+)
+
+// slogLevels maps the log levels to the slog levels
+var slogLevels = []slog.Level{
+	DEBUG:   slog.LevelDebug,
+	INFO:    slog.LevelInfo,
+	WARNING: slog.LevelWarn,
+	ERROR:   slog.LevelError,
+}
+
+var (
+	handlerMu sync.RWMutex
+	format    = TextFormat
+	handler   slog.Handler
+	// jsonHandler writes to the current output of the logger, see SetOutput.
+	// The log level is checked before the records are handled.
+	jsonHandler = slog.NewJSONHandler(outputWriter{}, &slog.HandlerOptions{Level: slog.LevelDebug})
+)
+
+// outputWriter writes to the current output of the logger
+type outputWriter struct{}
+
+func (outputWriter) Write(p []byte) (int, error) {
+	return logger.Writer().Write(p)
+}
+
+// ToFormat converts a string to a log format, or returns false if it's not
+// a valid format. An empty string is the text format.
+func ToFormat(s string) (Format, bool) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case "", TextFormat:
+		return TextFormat, true
+	case JSONFormat:
+		return JSONFormat, true
+	}
+	return TextFormat, false
+}
+
+// SetFormat sets the output format of the internal logger. It's ignored when
+// a handler is set with SetHandler.
+func SetFormat(f Format) {
+	handlerMu.Lock()
+	defer handlerMu.Unlock()
+	format = f
+}
+
+// SetFormatFromStr parses the input string to a Format and changes the output
+// format of the internal logger accordingly. Invalid formats fall back to the
+// text format.
+func SetFormatFromStr(s string) {
+	f, _ := ToFormat(s)
+	SetFormat(f)
+}
+
+// SetHandler routes the logs to the slog handler h rather than to the output
+// of the internal logger. The logs carry the `component` of the library
+// logging them and the `error` they report, if any, as attributes. The log
+// level of the library still applies. A nil handler restores the output.
+func SetHandler(h slog.Handler) {
+	handlerMu.Lock()
+	defer handlerMu.Unlock()
+	handler = h
+}
+
+// currentHandler returns the handler of the structured logs, or nil if the
+// logs are printed as text
+func currentHandler() slog.Handler {
+	handlerMu.RLock()
+	defer handlerMu.RUnlock()
+	if handler != nil {
+		return handler
+	}
+	if format == JSONFormat {
+		return jsonHandler
+	}
+	return nil
+}
+
+// handleRecord passes the log message s to the slog handler h, with the
+// component and the first error of args as attributes
+func handleRecord(h slog.Handler, level LogLevel, component string, s string, args []interface{}) {
+	ctx := context.Background()
+	if !h.Enabled(ctx, slogLevels[level]) {
+		return
+	}
+	// skip runtime.Callers, handleRecord, output, logIt and its wrapper
+	var pcs [1]uintptr
+	runtime.Callers(5, pcs[:])
+
+	r := slog.NewRecord(time.Now(), slogLevels[level], s, pcs[0])
+	r.AddAttrs(slog.String("logger", "solarwinds_apm"))
+	if component != "" {
+		r.AddAttrs(slog.String("component", component))
+	}
+	for _, arg := range args {
+		if err, ok := arg.(error); ok {
+			r.AddAttrs(slog.Any("error", err))
+			break
+		}
+	}
+	_ = h.Handle(ctx, r)
+}
+
+// Logger prints the logs of a component of the library, e.g. the sampler.
+// The component is an attribute of the structured logs.
+type Logger struct {
+	component string
+}
+
+// WithComponent returns a Logger for the component of the library
+func WithComponent(component string) *Logger {
+	return &Logger{component: component}
+}
+
+// Debugf formats the log message with specified args
+// and print it in the specified level
+func (l *Logger) Debugf(msg string, args ...interface{}) {
+	logIt(DEBUG, l.component, msg, args)
+}
+
+// Debug prints the log message in the specified level
+func (l *Logger) Debug(args ...interface{}) {
+	logIt(DEBUG, l.component, "", args)
+}
+
+// Infof formats the log message with specified args
+// and print it in the specified level
+func (l *Logger) Infof(msg string, args ...interface{}) {
+	logIt(INFO, l.component, msg, args)
+}
+
+// Info prints the log message in the specified level
+func (l *Logger) Info(args ...interface{}) {
+	logIt(INFO, l.component, "", args)
+}
+
+// Warningf formats the log message with specified args
+// and print it in the specified level
+func (l *Logger) Warningf(msg string, args ...interface{}) {
+	logIt(WARNING, l.component, msg, args)
+}
+
+// Warning prints the log message in the specified level
+func (l *Logger) Warning(args ...interface{}) {
+	logIt(WARNING, l.component, "", args)
+}
+
+// Errorf formats the log message with specified args
+// and print it in the specified level
+func (l *Logger) Errorf(msg string, args ...interface{}) {
+	logIt(ERROR, l.component, msg, args)
+}
+
+// Error prints the log message in the specified level
+func (l *Logger) Error(args ...interface{}) {
+	logIt(ERROR, l.component, "", args)
+}
@@ -0,0 +1,148 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToFormat(t *testing.T) {
+	for s, expected := range map[string]Format{
+		"":       TextFormat,
+		"text":   TextFormat,
+		" JSON ": JSONFormat,
+	} {
+		f, ok := ToFormat(s)
+		assert.True(t, ok, s)
+		assert.Equal(t, expected, f, s)
+	}
+	f, ok := ToFormat("xml")
+	assert.False(t, ok)
+	assert.Equal(t, TextFormat, f)
+}
+
+func TestJSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	SetFormat(JSONFormat)
+	oldLevel := Level()
+	SetLevel(INFO)
+	defer func() {
+		SetOutput(os.Stderr)
+		SetFormat(TextFormat)
+		SetLevel(oldLevel)
+	}()
+
+	Debug("filtered by the log level")
+	assert.Empty(t, buf.String())
+
+	WithComponent("settings").Warningf("failed to retrieve the settings: %v", errors.New("timeout"))
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "WARN", record["level"])
+	assert.Equal(t, "failed to retrieve the settings: timeout", record["msg"])
+	assert.Equal(t, "settings", record["component"])
+	assert.Equal(t, "timeout", record["error"])
+	assert.Equal(t, "solarwinds_apm", record["logger"])
+	assert.Contains(t, record, "time")
+
+	buf.Reset()
+	Info("hello")
+	record = nil
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, "hello", record["msg"])
+	assert.NotContains(t, record, "component")
+	assert.NotContains(t, record, "error")
+
+	// Back to text
+	buf.Reset()
+	SetFormat(TextFormat)
+	Info("hello")
+	assert.Contains(t, buf.String(), "INFO  [solarwinds_apm] hello\n")
+}
+
+// recordingHandler keeps the records it handles
+type recordingHandler struct {
+	level   slog.Level
+	records []slog.Record
+}
+
+func (h *recordingHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *recordingHandler) Handle(_ context.Context, r slog.Record) error {
+	h.records = append(h.records, r)
+	return nil
+}
+
+func (h *recordingHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h *recordingHandler) WithGroup(string) slog.Handler      { return h }
+
+func TestSetHandler(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	// The handler takes precedence over the format
+	SetFormat(JSONFormat)
+	oldLevel := Level()
+	SetLevel(DEBUG)
+	h := &recordingHandler{level: slog.LevelInfo}
+	SetHandler(h)
+	defer func() {
+		SetHandler(nil)
+		SetOutput(os.Stderr)
+		SetFormat(TextFormat)
+		SetLevel(oldLevel)
+	}()
+
+	sampler := WithComponent("sampler")
+	sampler.Debugf("filtered by the handler")
+	sampler.Errorf("invalid sample rate: %d", 2000000)
+	require.Len(t, h.records, 1)
+	r := h.records[0]
+	assert.Equal(t, slog.LevelError, r.Level)
+	assert.Equal(t, "invalid sample rate: 2000000", r.Message)
+	attrs := map[string]string{}
+	r.Attrs(func(a slog.Attr) bool {
+		attrs[a.Key] = a.Value.String()
+		return true
+	})
+	assert.Equal(t, map[string]string{"logger": "solarwinds_apm", "component": "sampler"}, attrs)
+	// The source is the caller of the logger
+	frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+	assert.Equal(t, "github.com/solarwinds/apm-go/internal/log.TestSetHandler", frame.Function)
+	assert.Empty(t, buf.String())
+
+	// The log level of the library applies first
+	SetLevel(ERROR)
+	Warning("filtered by the log level")
+	assert.Len(t, h.records, 1)
+
+	SetHandler(nil)
+	SetFormat(TextFormat)
+	Error("to the output")
+	assert.Contains(t, buf.String(), "to the output")
+}
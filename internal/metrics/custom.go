@@ -0,0 +1,172 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sync"
+
+	"github.com/solarwinds/apm-go/internal/constants"
+	"github.com/solarwinds/apm-go/internal/txn"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	customMetricsMeterName = "sw.apm.custom.metrics"
+	// MaxCustomMetricAttributes is the maximum number of attributes of a
+	// custom metric measurement, sw.transaction excluded
+	MaxCustomMetricAttributes = 50
+	// MaxCustomMetrics is the maximum number of distinct custom metric names
+	MaxCustomMetrics = 500
+)
+
+var (
+	ErrInvalidMetricName  = errors.New("invalid metric name")
+	ErrInvalidMetricValue = errors.New("invalid metric value")
+	ErrTooManyAttributes  = fmt.Errorf("too many metric attributes, the limit is %d", MaxCustomMetricAttributes)
+	ErrTooManyMetrics     = fmt.Errorf("too many custom metrics, the limit is %d", MaxCustomMetrics)
+	ErrMetricKindConflict = errors.New("metric name already used by a metric of another kind")
+
+	// metricNameRegex follows the otel instrument name syntax
+	metricNameRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_./-]{0,254}$`)
+)
+
+// CustomMetrics records the metrics reported by the user, e.g. business
+// metrics. The measurements carry the transaction name of the current trace.
+type CustomMetrics struct {
+	meter metric.Meter
+
+	mut        sync.Mutex
+	counters   map[string]metric.Int64Counter
+	histograms map[string]metric.Float64Histogram
+}
+
+// NewCustomMetrics returns the custom metrics recorded with the meter provider
+func NewCustomMetrics(p metric.MeterProvider) *CustomMetrics {
+	return &CustomMetrics{
+		meter:      p.Meter(customMetricsMeterName),
+		counters:   make(map[string]metric.Int64Counter),
+		histograms: make(map[string]metric.Float64Histogram),
+	}
+}
+
+// Increment adds count to the counter named name
+func (c *CustomMetrics) Increment(ctx context.Context, name string, count int64, attrs ...attribute.KeyValue) error {
+	if count < 0 {
+		return fmt.Errorf("%w: negative count %d", ErrInvalidMetricValue, count)
+	}
+	set, err := customMetricAttributes(ctx, attrs)
+	if err != nil {
+		return err
+	}
+	counter, err := c.counter(name)
+	if err != nil {
+		return err
+	}
+	counter.Add(ctx, count, metric.WithAttributeSet(set))
+	return nil
+}
+
+// Summary records value in the histogram named name
+func (c *CustomMetrics) Summary(ctx context.Context, name string, value float64, attrs ...attribute.KeyValue) error {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return fmt.Errorf("%w: %v", ErrInvalidMetricValue, value)
+	}
+	set, err := customMetricAttributes(ctx, attrs)
+	if err != nil {
+		return err
+	}
+	histo, err := c.histogram(name)
+	if err != nil {
+		return err
+	}
+	histo.Record(ctx, value, metric.WithAttributeSet(set))
+	return nil
+}
+
+func (c *CustomMetrics) counter(name string) (metric.Int64Counter, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if counter, ok := c.counters[name]; ok {
+		return counter, nil
+	}
+	if err := c.checkNewMetric(name); err != nil {
+		return nil, err
+	}
+	counter, err := c.meter.Int64Counter(name)
+	if err != nil {
+		return nil, err
+	}
+	c.counters[name] = counter
+	return counter, nil
+}
+
+func (c *CustomMetrics) histogram(name string) (metric.Float64Histogram, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if histo, ok := c.histograms[name]; ok {
+		return histo, nil
+	}
+	if err := c.checkNewMetric(name); err != nil {
+		return nil, err
+	}
+	histo, err := c.meter.Float64Histogram(name)
+	if err != nil {
+		return nil, err
+	}
+	c.histograms[name] = histo
+	return histo, nil
+}
+
+// checkNewMetric validates the name of a metric before its instrument is
+// created. It must be called with the lock held.
+func (c *CustomMetrics) checkNewMetric(name string) error {
+	if !metricNameRegex.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidMetricName, name)
+	}
+	_, isCounter := c.counters[name]
+	_, isHisto := c.histograms[name]
+	if isCounter || isHisto {
+		return fmt.Errorf("%w: %q", ErrMetricKindConflict, name)
+	}
+	if len(c.counters)+len(c.histograms) >= MaxCustomMetrics {
+		return ErrTooManyMetrics
+	}
+	return nil
+}
+
+// customMetricAttributes validates the user attributes and adds the
+// transaction name of the trace in ctx, which cannot be overridden.
+func customMetricAttributes(ctx context.Context, attrs []attribute.KeyValue) (attribute.Set, error) {
+	kvs := make([]attribute.KeyValue, 0, len(attrs)+1)
+	for _, attr := range attrs {
+		if !attr.Valid() || attr.Key == constants.SwTransactionNameAttribute {
+			continue
+		}
+		kvs = append(kvs, attr)
+	}
+	if len(kvs) > MaxCustomMetricAttributes {
+		return attribute.Set{}, ErrTooManyAttributes
+	}
+	if txnName := txn.CurrentTransactionName(ctx); txnName != "" {
+		kvs = append(kvs, attribute.String(constants.SwTransactionNameAttribute, txnName))
+	}
+	return attribute.NewSet(kvs...), nil
+}
@@ -0,0 +1,117 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/solarwinds/apm-go/internal/constants"
+	"github.com/solarwinds/apm-go/internal/entryspans"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func collectCustomMetrics(t *testing.T, reader sdkmetric.Reader) map[string]metricdata.Aggregation {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	result := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		require.Equal(t, customMetricsMeterName, sm.Scope.Name)
+		for _, m := range sm.Metrics {
+			result[m.Name] = m.Data
+		}
+	}
+	return result
+}
+
+func TestCustomMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	m := NewCustomMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	tracer := sdktrace.NewTracerProvider().Tracer("test")
+	ctx, span := tracer.Start(context.Background(), "entry")
+	require.NoError(t, entryspans.Push(span.(sdktrace.ReadWriteSpan)))
+	defer func() { require.NoError(t, entryspans.Delete(span.(sdktrace.ReadOnlySpan))) }()
+	require.NoError(t, entryspans.SetTransactionName(span.SpanContext().TraceID(), "checkout"))
+
+	require.NoError(t, m.Increment(ctx, "orders.placed", 2, attribute.String("region", "eu"),
+		attribute.String(constants.SwTransactionNameAttribute, "spoofed")))
+	require.NoError(t, m.Increment(ctx, "orders.placed", 3, attribute.String("region", "eu")))
+	require.NoError(t, m.Summary(ctx, "orders.amount", 12.5))
+	require.NoError(t, m.Summary(context.Background(), "orders.amount", 7.5))
+
+	data := collectCustomMetrics(t, reader)
+	require.Len(t, data, 2)
+	sum, ok := data["orders.placed"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 1)
+	require.Equal(t, int64(5), sum.DataPoints[0].Value)
+	require.Equal(t, attribute.NewSet(
+		attribute.String("region", "eu"),
+		attribute.String(constants.SwTransactionNameAttribute, "checkout"),
+	), sum.DataPoints[0].Attributes)
+
+	histo, ok := data["orders.amount"].(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, histo.DataPoints, 2)
+	sets := []attribute.Set{histo.DataPoints[0].Attributes, histo.DataPoints[1].Attributes}
+	require.ElementsMatch(t, []attribute.Set{
+		attribute.NewSet(attribute.String(constants.SwTransactionNameAttribute, "checkout")),
+		attribute.NewSet(),
+	}, sets)
+}
+
+func TestCustomMetricsValidation(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	m := NewCustomMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	ctx := context.Background()
+
+	for _, name := range []string{"", "1metric", "my metric", "metric!", strings.Repeat("a", 256)} {
+		require.ErrorIs(t, m.Increment(ctx, name, 1), ErrInvalidMetricName, name)
+		require.ErrorIs(t, m.Summary(ctx, name, 1), ErrInvalidMetricName, name)
+	}
+	require.NoError(t, m.Increment(ctx, strings.Repeat("a", 255), 1))
+	require.ErrorIs(t, m.Increment(ctx, "count", -1), ErrInvalidMetricValue)
+	for _, value := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		require.ErrorIs(t, m.Summary(ctx, "summary", value), ErrInvalidMetricValue)
+	}
+
+	require.NoError(t, m.Increment(ctx, "count", 1))
+	require.ErrorIs(t, m.Summary(ctx, "count", 1), ErrMetricKindConflict)
+
+	attrs := make([]attribute.KeyValue, MaxCustomMetricAttributes+1)
+	for i := range attrs {
+		attrs[i] = attribute.Int(fmt.Sprintf("key%d", i), i)
+	}
+	require.ErrorIs(t, m.Increment(ctx, "count", 1, attrs...), ErrTooManyAttributes)
+	require.NoError(t, m.Increment(ctx, "count", 1, attrs[:MaxCustomMetricAttributes]...))
+	// Invalid attributes are dropped and not counted
+	require.NoError(t, m.Increment(ctx, "count", 1, append(attrs[:MaxCustomMetricAttributes], attribute.KeyValue{})...))
+
+	for i := len(m.counters) + len(m.histograms); i < MaxCustomMetrics; i++ {
+		require.NoError(t, m.Increment(ctx, fmt.Sprintf("metric%d", i), 1))
+	}
+	require.ErrorIs(t, m.Increment(ctx, "one.too.many", 1), ErrTooManyMetrics)
+	// Existing metrics are still recorded
+	require.NoError(t, m.Increment(ctx, "count", 1))
+	require.Len(t, collectCustomMetrics(t, reader), MaxCustomMetrics)
+}
@@ -17,6 +17,7 @@ package metrics
 import (
 	"context"
 
+	"github.com/solarwinds/apm-go/internal/config"
 	"github.com/solarwinds/apm-go/internal/constants"
 	"github.com/solarwinds/apm-go/internal/swotel/semconv"
 	"github.com/solarwinds/apm-go/internal/txn"
@@ -27,8 +28,15 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+const (
+	requestMetricsMeterName   = "sw.apm.request.metrics"
+	responseTimeHistogramName = "trace.service.response_time"
+)
+
 type otelRegistry struct {
-	histo metric.Int64Histogram
+	histo        metric.Int64Histogram
+	microseconds bool
+	limiter      *transactionNameLimiter
 }
 
 var searchSet = map[attribute.Key]bool{
@@ -40,35 +48,72 @@ var searchSet = map[attribute.Key]bool{
 }
 
 func (o *otelRegistry) RecordSpan(span sdktrace.ReadOnlySpan) {
+	txnName, overflowed := o.limiter.check(txn.GetTransactionName(span))
 	var attrs = []attribute.KeyValue{
 		attribute.Bool("sw.is_error", span.Status().Code == codes.Error),
-		attribute.String(constants.SwTransactionNameAttribute, txn.GetTransactionName(span)),
+		attribute.String(constants.SwTransactionNameAttribute, txnName),
 	}
 	if span.SpanKind() == trace.SpanKindServer {
 		for _, attr := range span.Attributes() {
+			// The route is as distinct as the transaction name
+			if overflowed && attr.Key == semconv.HTTPRouteKey {
+				continue
+			}
 			if searchSet[attr.Key] {
 				attrs = append(attrs, attr)
 			}
 		}
 	}
-	duration := span.EndTime().Sub(span.StartTime())
 	o.histo.Record(
 		context.Background(),
-		duration.Milliseconds(),
+		spanDuration(span, o.microseconds),
 		metric.WithAttributes(attrs...),
 	)
 }
 
+// spanDuration returns the duration of the span in either microseconds or
+// milliseconds
+func spanDuration(span sdktrace.ReadOnlySpan, microseconds bool) int64 {
+	duration := span.EndTime().Sub(span.StartTime())
+	if microseconds {
+		return duration.Microseconds()
+	}
+	return duration.Milliseconds()
+}
+
 var _ MetricRegistry = &otelRegistry{}
 
 func NewOtelRegistry(p metric.MeterProvider) (MetricRegistry, error) {
-	meter := p.Meter("sw.apm.request.metrics")
-	if histo, err := meter.Int64Histogram(
-		"trace.service.response_time",
-		metric.WithUnit("ms"),
-	); err != nil {
+	meter := p.Meter(requestMetricsMeterName)
+	unit := config.GetHistogramUnit()
+	histo, err := meter.Int64Histogram(
+		responseTimeHistogramName,
+		metric.WithUnit(unit),
+	)
+	if err != nil {
+		return nil, err
+	}
+	limiter := newTransactionNameLimiter(config.GetTransactionMetricsLimit())
+	// The callback is called on each collect of the reader, so that the
+	// transaction names are limited per export
+	_, err = meter.Int64ObservableCounter(
+		"trace.service.transaction_name.overflow",
+		metric.WithUnit("{request}"),
+		metric.WithDescription("The number of requests recorded as the `other` transaction "+
+			"because the limit of distinct transaction names was reached"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			if overflow := limiter.reset(); overflow > 0 {
+				o.Observe(overflow)
+			}
+			return nil
+		}),
+	)
+	if err != nil {
 		return nil, err
-	} else {
-		return &otelRegistry{histo: histo}, nil
 	}
+	return &otelRegistry{
+		histo:        histo,
+		microseconds: unit == config.HistogramUnitMicroseconds,
+		limiter:      limiter,
+	}, nil
 }
@@ -0,0 +1,98 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/solarwinds/apm-go/internal/constants"
+	"github.com/solarwinds/apm-go/internal/swotel/semconv"
+	"github.com/solarwinds/apm-go/internal/txn"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestOtelRegistryLimitsTransactionNames(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	registry, err := NewOtelRegistry(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	require.NoError(t, err)
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+	for i := range config.GetTransactionMetricsLimit() + 5 {
+		_, span := tracer.Start(context.Background(), "GET", trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPRouteKey.String(fmt.Sprintf("/route/%d", i))))
+		span.End()
+	}
+	for _, span := range recorder.Ended() {
+		registry.RecordSpan(span)
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	var transactions, others int
+	var overflow int64
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch m.Name {
+		case "trace.service.response_time":
+			histo, ok := m.Data.(metricdata.Histogram[int64])
+			require.True(t, ok)
+			for _, dp := range histo.DataPoints {
+				transactions++
+				name, _ := dp.Attributes.Value(constants.SwTransactionNameAttribute)
+				if name.AsString() == txn.OtherTransactionName {
+					others++
+					require.Equal(t, uint64(5), dp.Count)
+					require.False(t, dp.Attributes.HasValue(semconv.HTTPRouteKey))
+				}
+			}
+		case "trace.service.transaction_name.overflow":
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			require.True(t, ok)
+			require.Len(t, sum.DataPoints, 1)
+			overflow = sum.DataPoints[0].Value
+		}
+	}
+	require.Equal(t, config.GetTransactionMetricsLimit()+1, transactions)
+	require.Equal(t, 1, others)
+	require.Equal(t, int64(5), overflow)
+
+	// The transaction names are limited per collect
+	_, span := tracer.Start(context.Background(), "GET", trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(semconv.HTTPRouteKey.String("/route/new")))
+	span.End()
+	registry.RecordSpan(recorder.Ended()[len(recorder.Ended())-1])
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name == "trace.service.response_time" {
+			histo := m.Data.(metricdata.Histogram[int64])
+			var found bool
+			for _, dp := range histo.DataPoints {
+				name, _ := dp.Attributes.Value(constants.SwTransactionNameAttribute)
+				found = found || name.AsString() == "/route/new"
+			}
+			require.True(t, found)
+		}
+	}
+}
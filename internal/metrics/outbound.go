@@ -0,0 +1,95 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+
+	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/solarwinds/apm-go/internal/swotel/semconv"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	outboundResponseTimeHistogramName = "trace.service.outbound.response_time"
+	outboundErrorsCounterName         = "trace.service.outbound.errors"
+)
+
+// outboundSearchSet defines the attributes identifying the downstream service
+// of a client or producer span
+var outboundSearchSet = map[attribute.Key]bool{
+	semconv.ServerAddressKey:            true,
+	semconv.DBSystemKey:                 true,
+	semconv.DBSystemNameKey:             true,
+	semconv.RPCServiceKey:               true,
+	semconv.RPCMethodKey:                true,
+	semconv.MessagingDestinationNameKey: true,
+}
+
+// outboundRegistry records the duration and the errors of the outbound
+// requests, i.e. the client and producer spans.
+type outboundRegistry struct {
+	histo        metric.Int64Histogram
+	microseconds bool
+	errors       metric.Int64Counter
+}
+
+var _ MetricRegistry = &outboundRegistry{}
+
+func (o *outboundRegistry) RecordSpan(span sdktrace.ReadOnlySpan) {
+	isError := span.Status().Code == codes.Error
+	attrs := []attribute.KeyValue{attribute.Bool("sw.is_error", isError)}
+	for _, attr := range span.Attributes() {
+		if outboundSearchSet[attr.Key] {
+			attrs = append(attrs, attr)
+		}
+	}
+	ctx := context.Background()
+	o.histo.Record(ctx, spanDuration(span, o.microseconds), metric.WithAttributes(attrs...))
+	if isError {
+		o.errors.Add(ctx, 1, metric.WithAttributes(attrs[1:]...))
+	}
+}
+
+// NewOtelOutboundRegistry returns the registry recording the metrics of the
+// outbound requests.
+func NewOtelOutboundRegistry(p metric.MeterProvider) (MetricRegistry, error) {
+	meter := p.Meter(requestMetricsMeterName)
+	unit := config.GetHistogramUnit()
+	histo, err := meter.Int64Histogram(
+		outboundResponseTimeHistogramName,
+		metric.WithUnit(unit),
+		metric.WithDescription("The duration of the outbound requests"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	errCounter, err := meter.Int64Counter(
+		outboundErrorsCounterName,
+		metric.WithUnit("{request}"),
+		metric.WithDescription("The number of the outbound requests which failed"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &outboundRegistry{
+		histo:        histo,
+		microseconds: unit == config.HistogramUnitMicroseconds,
+		errors:       errCounter,
+	}, nil
+}
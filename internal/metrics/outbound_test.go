@@ -0,0 +1,94 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/solarwinds/apm-go/internal/swotel/semconv"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestOutboundRegistry(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	registry, err := NewOtelOutboundRegistry(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	require.NoError(t, err)
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+	dbAttrs := trace.WithAttributes(
+		semconv.DBSystemKey.String("postgresql"),
+		semconv.ServerAddressKey.String("db.internal"),
+		semconv.DBQueryTextKey.String("SELECT 1"),
+	)
+	for range 3 {
+		_, span := tracer.Start(context.Background(), "SELECT", trace.WithSpanKind(trace.SpanKindClient), dbAttrs)
+		span.End()
+	}
+	_, span := tracer.Start(context.Background(), "SELECT", trace.WithSpanKind(trace.SpanKindClient), dbAttrs)
+	span.SetStatus(codes.Error, "timeout")
+	span.End()
+	_, span = tracer.Start(context.Background(), "publish", trace.WithSpanKind(trace.SpanKindProducer), trace.WithAttributes(
+		semconv.MessagingDestinationNameKey.String("orders"),
+	))
+	span.End()
+	for _, s := range recorder.Ended() {
+		registry.RecordSpan(s)
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	dbSet := attribute.NewSet(
+		semconv.DBSystemKey.String("postgresql"),
+		semconv.ServerAddressKey.String("db.internal"),
+	)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch m.Name {
+		case outboundResponseTimeHistogramName:
+			histo, ok := m.Data.(metricdata.Histogram[int64])
+			require.True(t, ok)
+			counts := make(map[attribute.Distinct]uint64)
+			for _, dp := range histo.DataPoints {
+				counts[dp.Attributes.Equivalent()] = dp.Count
+			}
+			withError := func(isError bool, kvs ...attribute.KeyValue) attribute.Distinct {
+				set := attribute.NewSet(append(kvs, attribute.Bool("sw.is_error", isError))...)
+				return set.Equivalent()
+			}
+			require.Equal(t, map[attribute.Distinct]uint64{
+				withError(false, dbSet.ToSlice()...):                                   3,
+				withError(true, dbSet.ToSlice()...):                                    1,
+				withError(false, semconv.MessagingDestinationNameKey.String("orders")): 1,
+			}, counts)
+		case outboundErrorsCounterName:
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			require.True(t, ok)
+			require.Len(t, sum.DataPoints, 1)
+			require.Equal(t, int64(1), sum.DataPoints[0].Value)
+			require.Equal(t, dbSet, sum.DataPoints[0].Attributes)
+		default:
+			t.Fatalf("unexpected metric %s", m.Name)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"sync"
+
+	"github.com/solarwinds/apm-go/internal/txn"
+)
+
+// transactionNameLimiter bounds the number of distinct transaction names per
+// export. Once the limit is reached, the transactions not seen yet since the
+// last export are recorded as txn.OtherTransactionName.
+type transactionNameLimiter struct {
+	limit int
+
+	lock  sync.Mutex
+	names map[string]struct{}
+	// the total number of transactions recorded as txn.OtherTransactionName
+	overflow int64
+}
+
+func newTransactionNameLimiter(limit int) *transactionNameLimiter {
+	return &transactionNameLimiter{
+		limit: limit,
+		names: make(map[string]struct{}),
+	}
+}
+
+// check returns the transaction name to record, and true if the name
+// overflowed the limit.
+func (l *transactionNameLimiter) check(name string) (string, bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if _, ok := l.names[name]; ok {
+		return name, false
+	}
+	if len(l.names) >= l.limit {
+		l.overflow++
+		return txn.OtherTransactionName, true
+	}
+	l.names[name] = struct{}{}
+	return name, false
+}
+
+// reset forgets the names seen, which is called on each export. It returns
+// the total number of transactions which overflowed the limit so far.
+func (l *transactionNameLimiter) reset() int64 {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	clear(l.names)
+	return l.overflow
+}
@@ -0,0 +1,45 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/solarwinds/apm-go/internal/txn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionNameLimiter(t *testing.T) {
+	l := newTransactionNameLimiter(2)
+
+	check := func(name string, expected string, overflowed bool) {
+		t.Helper()
+		n, o := l.check(name)
+		require.Equal(t, expected, n)
+		require.Equal(t, overflowed, o)
+	}
+	check("/a", "/a", false)
+	check("/b", "/b", false)
+	check("/a", "/a", false)
+	check("/c", txn.OtherTransactionName, true)
+	check("/b", "/b", false)
+
+	// The names are reset on export, the overflow count is cumulative
+	require.Equal(t, int64(1), l.reset())
+	check("/c", "/c", false)
+	check("/d", "/d", false)
+	check("/a", txn.OtherTransactionName, true)
+	require.Equal(t, int64(2), l.reset())
+}
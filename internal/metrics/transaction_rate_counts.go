@@ -0,0 +1,50 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import "sync"
+
+// TransactionRateCounts counts the requests limited by the token buckets of the
+// transactions which have their own sampling settings. The number of
+// transactions is bounded by the local configuration.
+type TransactionRateCounts struct {
+	lock    sync.Mutex
+	limited map[string]int64
+}
+
+var transactionRateCountsAggregator = &TransactionRateCounts{}
+
+func TransactionRatesAggregator() *TransactionRateCounts {
+	return transactionRateCountsAggregator
+}
+
+func (c *TransactionRateCounts) LimitedInc(txn string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.limited == nil {
+		c.limited = make(map[string]int64)
+	}
+	c.limited[txn]++
+}
+
+// FlushLimited returns the limited counts keyed by the transaction name and
+// resets them.
+func (c *TransactionRateCounts) FlushLimited() map[string]int64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	limited := c.limited
+	c.limited = nil
+	return limited
+}
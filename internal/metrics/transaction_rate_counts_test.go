@@ -0,0 +1,33 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionRateCounts(t *testing.T) {
+	rc := &TransactionRateCounts{}
+	assert.Nil(t, rc.FlushLimited())
+
+	rc.LimitedInc("/api/orders")
+	rc.LimitedInc("/api/orders")
+	rc.LimitedInc("/api/users")
+
+	assert.Equal(t, map[string]int64{"/api/orders": 2, "/api/users": 1}, rc.FlushLimited())
+	assert.Nil(t, rc.FlushLimited())
+}
@@ -0,0 +1,105 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"math"
+	"os"
+
+	"github.com/solarwinds/apm-go/internal/config"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// The explicit bucket boundaries of the response time histogram
+var (
+	millisecondBoundaries = []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}
+	microsecondBoundaries = []float64{0, 100, 250, 500, 1000, 2500, 5000, 10000, 25000, 50000, 75000,
+		100000, 250000, 500000, 750000, 1000000, 2500000, 5000000, 7500000, 10000000}
+)
+
+// The bounds of the exponential histogram scale
+const (
+	minExponentialScale = 0
+	maxExponentialScale = 20
+	// exponentialMaxSize is the maximum number of buckets, the default of the SDK
+	exponentialMaxSize = 160
+)
+
+// The environment variable setting the default histogram aggregation of the
+// exporter, and its value for the exponential histogram
+const (
+	defaultAggregationEnv          = "OTEL_EXPORTER_OTLP_METRICS_DEFAULT_HISTOGRAM_AGGREGATION"
+	exponentialAggregationEnvValue = "base2_exponential_bucket_histogram"
+)
+
+// ResponseTimeViews returns the views configuring the aggregation of the
+// response time histograms, of both the inbound and the outbound requests,
+// according to HistogramAggregation, HistogramUnit and Precision. If
+// HistogramAggregation is not set, the default aggregation of the exporter
+// applies: the exponential histogram, whose scale is still set by Precision,
+// unless another one is set by defaultAggregationEnv.
+func ResponseTimeViews() []sdkmetric.View {
+	aggregation := config.GetHistogramAggregation()
+	if aggregation == "" {
+		if a := os.Getenv(defaultAggregationEnv); a != "" && a != exponentialAggregationEnvValue {
+			return nil
+		}
+		aggregation = config.HistogramAggregationExponential
+	}
+	stream := sdkmetric.Stream{
+		Aggregation: responseTimeAggregation(aggregation, config.GetHistogramUnit(), config.GetPrecision()),
+	}
+	var views []sdkmetric.View
+	for _, name := range []string{responseTimeHistogramName, outboundResponseTimeHistogramName} {
+		views = append(views, sdkmetric.NewView(
+			sdkmetric.Instrument{
+				Name:  name,
+				Scope: instrumentation.Scope{Name: requestMetricsMeterName},
+			},
+			stream,
+		))
+	}
+	return views
+}
+
+func responseTimeAggregation(aggregation string, unit string, precision int) sdkmetric.Aggregation {
+	if aggregation == config.HistogramAggregationExponential {
+		return sdkmetric.AggregationBase2ExponentialHistogram{
+			MaxSize:  exponentialMaxSize,
+			MaxScale: exponentialScale(precision),
+		}
+	}
+	boundaries := millisecondBoundaries
+	if unit == config.HistogramUnitMicroseconds {
+		boundaries = microsecondBoundaries
+	}
+	return sdkmetric.AggregationExplicitBucketHistogram{Boundaries: boundaries}
+}
+
+// exponentialScale returns the smallest scale whose relative error is at most
+// one unit of the precision-th significant decimal digit. The buckets of a
+// scale grow by a factor of base = 2^(2^-scale), so the relative error of a
+// bucket's midpoint is (base - 1) / (base + 1).
+func exponentialScale(precision int) int32 {
+	maxErr := math.Pow(10, -float64(precision))
+	for scale := minExponentialScale; scale < maxExponentialScale; scale++ {
+		base := math.Exp2(math.Exp2(-float64(scale)))
+		if (base-1)/(base+1) <= maxErr {
+			return int32(scale)
+		}
+	}
+	return maxExponentialScale
+}
@@ -0,0 +1,131 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestExponentialScale(t *testing.T) {
+	for precision, scale := range []int32{0, 2, 6, 9, 12, 16, 19} {
+		require.Equal(t, scale, exponentialScale(precision), "precision %d", precision)
+	}
+	require.Equal(t, int32(maxExponentialScale), exponentialScale(10))
+}
+
+func TestResponseTimeAggregation(t *testing.T) {
+	require.Equal(t, sdkmetric.AggregationExplicitBucketHistogram{Boundaries: millisecondBoundaries},
+		responseTimeAggregation(config.HistogramAggregationExplicit, config.HistogramUnitMilliseconds, 2))
+	require.Equal(t, sdkmetric.AggregationExplicitBucketHistogram{Boundaries: microsecondBoundaries},
+		responseTimeAggregation(config.HistogramAggregationExplicit, config.HistogramUnitMicroseconds, 2))
+	require.Equal(t, sdkmetric.AggregationBase2ExponentialHistogram{MaxSize: exponentialMaxSize, MaxScale: 9},
+		responseTimeAggregation(config.HistogramAggregationExponential, config.HistogramUnitMicroseconds, 3))
+}
+
+func TestResponseTimeViewsDefault(t *testing.T) {
+	// The exponential aggregation of the exporter, with the scale set by
+	// Precision
+	config.Load(func(c *config.Config) { c.Precision = 3 })
+	requireDefaultExponentialScale(t, 9)
+	t.Setenv(defaultAggregationEnv, exponentialAggregationEnvValue)
+	requireDefaultExponentialScale(t, 9)
+	// Another default aggregation of the exporter applies as is
+	t.Setenv(defaultAggregationEnv, "explicit_bucket_histogram")
+	require.Empty(t, ResponseTimeViews())
+
+	config.Load(func(c *config.Config) {
+		c.HistogramUnit = config.HistogramUnitMicroseconds
+		c.HistogramAggregation = config.HistogramAggregationExplicit
+	})
+	t.Cleanup(func() { config.Load() })
+	require.Len(t, ResponseTimeViews(), 2)
+
+	// The other histograms of the scope keep the default aggregation
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithView(ResponseTimeViews()...))
+	histo, err := mp.Meter(requestMetricsMeterName).Int64Histogram("other")
+	require.NoError(t, err)
+	histo.Record(context.Background(), 1)
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	data, ok := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[int64])
+	require.True(t, ok)
+	require.NotEqual(t, microsecondBoundaries, data.DataPoints[0].Bounds)
+}
+
+func requireDefaultExponentialScale(t *testing.T, scale int32) {
+	t.Helper()
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithView(ResponseTimeViews()...))
+	histo, err := mp.Meter(requestMetricsMeterName).Float64Histogram(responseTimeHistogramName)
+	require.NoError(t, err)
+	// Close enough not to downscale the histogram
+	for _, v := range []float64{1, 1.001} {
+		histo.Record(context.Background(), v)
+	}
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	data, ok := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.ExponentialHistogram[float64])
+	require.True(t, ok)
+	require.Equal(t, scale, data.DataPoints[0].Scale)
+}
+
+func TestResponseTimeViewMicrosecondsExponential(t *testing.T) {
+	config.Load(func(c *config.Config) {
+		c.HistogramUnit = config.HistogramUnitMicroseconds
+		c.HistogramAggregation = config.HistogramAggregationExponential
+	})
+	t.Cleanup(func() { config.Load() })
+
+	reader := sdkmetric.NewManualReader()
+	registry, err := NewOtelRegistry(sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(reader),
+		sdkmetric.WithView(ResponseTimeViews()...),
+	))
+	require.NoError(t, err)
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+	start := time.Now()
+	_, span := tracer.Start(context.Background(), "GET", trace.WithTimestamp(start))
+	span.End(trace.WithTimestamp(start.Add(250 * time.Microsecond)))
+	registry.RecordSpan(recorder.Ended()[0])
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name != responseTimeHistogramName {
+			continue
+		}
+		require.Equal(t, config.HistogramUnitMicroseconds, m.Unit)
+		histo, ok := m.Data.(metricdata.ExponentialHistogram[int64])
+		require.True(t, ok)
+		require.Len(t, histo.DataPoints, 1)
+		require.Equal(t, int64(250), histo.DataPoints[0].Sum)
+		require.LessOrEqual(t, histo.DataPoints[0].Scale, exponentialScale(config.GetPrecision()))
+		return
+	}
+	t.Fatal("response time histogram not found")
+}
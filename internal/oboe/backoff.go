@@ -0,0 +1,66 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oboe
+
+import (
+	"time"
+
+	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/solarwinds/apm-go/internal/rand"
+)
+
+// backoff computes the delays between the retries of a failed operation.
+type backoff struct {
+	initial    time.Duration
+	max        time.Duration
+	maxRetries int
+	// the number of consecutive failures between two warnings
+	logThreshold int
+}
+
+func newBackoff(opts *config.ReporterOptions) backoff {
+	b := backoff{
+		initial:      time.Duration(opts.RetryDelayInitial) * time.Millisecond,
+		max:          time.Duration(opts.RetryDelayMax) * time.Second,
+		maxRetries:   max(opts.MaxRetries, 0),
+		logThreshold: max(opts.RetryLogThreshold, 1),
+	}
+	if b.initial <= 0 {
+		b.initial = time.Millisecond
+	}
+	if b.max < b.initial {
+		b.max = b.initial
+	}
+	return b
+}
+
+// delay returns the delay before the given retry, starting at 1. The delay
+// doubles on each retry up to the max delay, and a random jitter of up to half
+// of it is applied so that the clients don't retry in lockstep.
+func (b backoff) delay(retry int) time.Duration {
+	d := b.initial
+	for i := 1; i < retry && d < b.max; i++ {
+		d *= 2
+	}
+	d = min(d, b.max)
+	half := d / 2
+	return d - half + time.Duration(rand.RandInt63n(int64(half)+1))
+}
+
+// shouldWarn returns if the given number of consecutive failures should be
+// logged as a warning: the first one and then every logThreshold failures.
+func (b backoff) shouldWarn(failures int) bool {
+	return failures == 1 || b.logThreshold <= 1 || failures%b.logThreshold == 0
+}
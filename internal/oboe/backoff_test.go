@@ -0,0 +1,82 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oboe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBackoff(t *testing.T) {
+	b := newBackoff(&config.ReporterOptions{
+		RetryDelayInitial: 500,
+		RetryDelayMax:     60,
+		RetryLogThreshold: 10,
+		MaxRetries:        20,
+	})
+	require.Equal(t, backoff{
+		initial:      500 * time.Millisecond,
+		max:          time.Minute,
+		maxRetries:   20,
+		logThreshold: 10,
+	}, b)
+
+	b = newBackoff(&config.ReporterOptions{MaxRetries: -1})
+	require.Equal(t, backoff{
+		initial:      time.Millisecond,
+		max:          time.Millisecond,
+		maxRetries:   0,
+		logThreshold: 1,
+	}, b)
+}
+
+func TestBackoffDelay(t *testing.T) {
+	b := backoff{initial: 500 * time.Millisecond, max: time.Minute}
+	for _, tc := range []struct {
+		retry    int
+		expected time.Duration
+	}{
+		{1, 500 * time.Millisecond},
+		{2, time.Second},
+		{3, 2 * time.Second},
+		{7, 32 * time.Second},
+		{8, time.Minute},
+		{20, time.Minute},
+		{1000, time.Minute},
+	} {
+		for range 100 {
+			d := b.delay(tc.retry)
+			require.GreaterOrEqual(t, d, tc.expected/2, "retry %d", tc.retry)
+			require.LessOrEqual(t, d, tc.expected, "retry %d", tc.retry)
+		}
+	}
+}
+
+func TestBackoffShouldWarn(t *testing.T) {
+	b := backoff{logThreshold: 10}
+	var warned []int
+	for failures := 1; failures <= 30; failures++ {
+		if b.shouldWarn(failures) {
+			warned = append(warned, failures)
+		}
+	}
+	require.Equal(t, []int{1, 10, 20, 30}, warned)
+
+	b = backoff{}
+	require.True(t, b.shouldWarn(2))
+}
@@ -0,0 +1,114 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oboe
+
+import "time"
+
+// SettingsDiagnostics is a snapshot of the sampling settings, exposed for
+// troubleshooting purposes.
+type SettingsDiagnostics struct {
+	Flags      []string     `json:"flags"`
+	SampleRate int          `json:"sampleRate"`
+	Source     SampleSource `json:"sampleSource"`
+	Timestamp  time.Time    `json:"timestamp"`
+	TTL        string       `json:"ttl"`
+	Expires    time.Time    `json:"expires"`
+	// The token buckets keyed by `default`, `triggerRelaxed` and `triggerStrict`
+	Buckets map[string]BucketDiagnostics `json:"buckets"`
+	// The per-transaction settings keyed by the transaction name
+	Transactions map[string]TransactionDiagnostics `json:"transactions,omitempty"`
+}
+
+// BucketDiagnostics is a snapshot of a token bucket.
+type BucketDiagnostics struct {
+	Capacity  float64 `json:"capacity"`
+	Rate      float64 `json:"rate"`
+	Available float64 `json:"available"`
+}
+
+// TransactionDiagnostics is a snapshot of the settings of a transaction.
+type TransactionDiagnostics struct {
+	Flags      []string          `json:"flags"`
+	SampleRate int               `json:"sampleRate"`
+	Source     SampleSource      `json:"sampleSource"`
+	Bucket     BucketDiagnostics `json:"bucket"`
+}
+
+// GetSettingsDiagnostics returns a snapshot of the current settings of the
+// given Oboe, or nil if there are no settings.
+func GetSettingsDiagnostics(o Oboe) *SettingsDiagnostics {
+	s := o.GetSetting()
+	if s == nil {
+		return nil
+	}
+	d := &SettingsDiagnostics{
+		Flags:      s.flags.names(),
+		SampleRate: s.value,
+		Source:     s.source,
+		Timestamp:  s.timestamp,
+		TTL:        s.ttl.String(),
+		Expires:    s.timestamp.Add(s.ttl),
+		Buckets: map[string]BucketDiagnostics{
+			"default":        s.bucket.diagnostics(),
+			"triggerRelaxed": s.triggerTraceRelaxedBucket.diagnostics(),
+			"triggerStrict":  s.triggerTraceStrictBucket.diagnostics(),
+		},
+	}
+	for name, ts := range s.transactions {
+		if d.Transactions == nil {
+			d.Transactions = make(map[string]TransactionDiagnostics, len(s.transactions))
+		}
+		d.Transactions[name] = TransactionDiagnostics{
+			Flags:      ts.flags.names(),
+			SampleRate: ts.value,
+			Source:     ts.source,
+			Bucket:     ts.bucket.diagnostics(),
+		}
+	}
+	return d
+}
+
+// diagnostics returns the bucket's capacity, rate and current fill level.
+func (b *tokenBucket) diagnostics() BucketDiagnostics {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.update(time.Now())
+	return BucketDiagnostics{
+		Capacity:  b.capacity,
+		Rate:      b.ratePerSec,
+		Available: b.available,
+	}
+}
+
+// names returns the names of the flags which are set, as used by the
+// settings API.
+func (f settingFlag) names() []string {
+	names := []string{}
+	for _, flag := range []struct {
+		flag settingFlag
+		name string
+	}{
+		{FlagOverride, "OVERRIDE"},
+		{FlagSampleStart, "SAMPLE_START"},
+		{FlagSampleThrough, "SAMPLE_THROUGH"},
+		{FlagSampleThroughAlways, "SAMPLE_THROUGH_ALWAYS"},
+		{FlagTriggerTrace, "TRIGGER_TRACE"},
+	} {
+		if f&flag.flag != 0 {
+			names = append(names, flag.name)
+		}
+	}
+	return names
+}
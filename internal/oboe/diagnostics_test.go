@@ -0,0 +1,75 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oboe
+
+import (
+	"testing"
+
+	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSettingsDiagnostics(t *testing.T) {
+	o := NewOboe()
+	require.Nil(t, GetSettingsDiagnostics(o))
+
+	args := GetDefaultSettingForTest()
+	args.BucketCapacity = 8
+	args.BucketRate = 0
+	o.UpdateSetting(args)
+	s := o.GetSetting()
+	s.bucket.available = 5
+	rate, capacity := 1000, 2.0
+	s.mergeTransactionSettings([]config.TransactionFilter{
+		{Type: config.Transaction, Name: "/api/orders", SampleRate: &rate, TokenBucketCap: &capacity},
+	})
+
+	d := GetSettingsDiagnostics(o)
+	require.NotNil(t, d)
+	require.Equal(t, []string{"SAMPLE_START", "SAMPLE_THROUGH_ALWAYS", "TRIGGER_TRACE"}, d.Flags)
+	require.Equal(t, 1000000, d.SampleRate)
+	require.Equal(t, SampleSourceDefault, d.Source)
+	require.Equal(t, "2m0s", d.TTL)
+	require.Equal(t, s.timestamp.Add(s.ttl), d.Expires)
+	require.Equal(t, BucketDiagnostics{Capacity: 8, Rate: 0, Available: 5}, d.Buckets["default"])
+	require.Equal(t, BucketDiagnostics{Capacity: 1000000, Rate: 1000000, Available: 1000000}, d.Buckets["triggerStrict"])
+	require.Equal(t, TransactionDiagnostics{
+		Flags:      d.Flags,
+		SampleRate: 1000,
+		Source:     SampleSourceFile,
+		Bucket:     BucketDiagnostics{Capacity: 2, Rate: 0},
+	}, d.Transactions["/api/orders"])
+}
+
+func TestSettingFlagNames(t *testing.T) {
+	require.Equal(t, []string{}, FlagOk.names())
+	require.Equal(t, []string{"OVERRIDE", "SAMPLE_THROUGH"}, (FlagOverride | FlagSampleThrough).names())
+}
+
+func TestSettingsUpdaterStatus(t *testing.T) {
+	su := &settingsUpdater{}
+	require.Equal(t, SettingsUpdaterStatus{}, su.Status())
+
+	su.setStatus(nil)
+	status := su.Status()
+	require.False(t, status.LastFetch.IsZero())
+	require.Equal(t, status.LastFetch, status.LastSuccess)
+	require.NoError(t, status.LastError)
+
+	su.setStatus(config.ErrInvalidServiceKey)
+	failed := su.Status()
+	require.Equal(t, status.LastSuccess, failed.LastSuccess)
+	require.ErrorIs(t, failed.LastError, config.ErrInvalidServiceKey)
+}
@@ -18,8 +18,6 @@ import (
 	"context"
 	"os"
 	"time"
-
-	"github.com/solarwinds/apm-go/internal/log"
 )
 
 const (
@@ -52,13 +50,13 @@ type fileBasedWatcher struct {
 func (w *fileBasedWatcher) readSettingFromFile() {
 	s, err := newSettingLambdaFromFile()
 	if os.IsNotExist(err) {
-		log.Debug("Settings file does not yet exist")
+		logger.Debug("Settings file does not yet exist")
 		return
 	} else if err != nil {
-		log.Errorf("Could not read setting from file: %s", err)
+		logger.Errorf("Could not read setting from file: %s", err)
 		return
 	}
-	log.Debugf(
+	logger.Debugf(
 		"Got lambda settings from file:\n%+v",
 		s,
 	)
@@ -85,7 +83,7 @@ func (w *fileBasedWatcher) Start() {
 }
 
 func (w *fileBasedWatcher) Stop() {
-	log.Info("Stopping settings file watcher.")
+	logger.Info("Stopping settings file watcher.")
 	exit <- true
 }
 
@@ -93,15 +91,15 @@ func waitForSettingsFile() {
 	var timeout = 1 * time.Second
 	if timeoutStr := os.Getenv(timeoutEnv); timeoutStr != "" {
 		if override, err := time.ParseDuration(timeoutStr); err != nil {
-			log.Errorf("could not parse duration from %s '%s': %s", timeoutEnv, timeoutStr, err)
+			logger.Errorf("could not parse duration from %s '%s': %s", timeoutEnv, timeoutStr, err)
 		} else if int64(override) < 1 {
-			log.Infof("%s was 0 or negative, skipping wait for settings file", timeoutEnv)
+			logger.Infof("%s was 0 or negative, skipping wait for settings file", timeoutEnv)
 			return
 		} else {
 			timeout = override
 		}
 	}
-	log.Debugf("Waiting for settings file for up to %s (override with %s; set to 0 to skip)", timeout, timeoutEnv)
+	logger.Debugf("Waiting for settings file for up to %s (override with %s; set to 0 to skip)", timeout, timeoutEnv)
 	// We could use something like fsnotify, but that's overkill for something this simple
 	waitTicker := time.NewTicker(10 * time.Millisecond)
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
@@ -113,18 +111,18 @@ func waitForSettingsFile() {
 			{
 				_, err := os.Stat(settingsFileName)
 				if err == nil {
-					log.Info("Settings file found")
+					logger.Info("Settings file found")
 					return
 				} else if os.IsNotExist(err) {
-					log.Debug("Settings file does not yet exist")
+					logger.Debug("Settings file does not yet exist")
 				} else {
-					log.Errorf("Could not read settings from file: %s", err)
+					logger.Errorf("Could not read settings from file: %s", err)
 					return
 				}
 			}
 		case <-ctx.Done():
 			{
-				log.Info("timed out waiting for settings file")
+				logger.Info("timed out waiting for settings file")
 				return
 			}
 		}
@@ -27,3 +27,7 @@ func (nsu *nullSettingsUpdater) Start(ctx context.Context) func() {
 		// no-op
 	}
 }
+
+func (nsu *nullSettingsUpdater) Status() SettingsUpdaterStatus {
+	return SettingsUpdaterStatus{}
+}
@@ -21,15 +21,20 @@ import (
 	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 
 	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/solarwinds/apm-go/internal/constants"
 	"github.com/solarwinds/apm-go/internal/log"
 	"github.com/solarwinds/apm-go/internal/metrics"
 	"github.com/solarwinds/apm-go/internal/rand"
 	"github.com/solarwinds/apm-go/internal/w3cfmt"
 )
 
+// logger prints the logs of the sampling settings and decisions
+var logger = log.WithComponent("oboe")
+
 const (
 	maxSamplingRate = config.MaxSampleRate
 )
@@ -61,22 +66,34 @@ type SettingsUpdateArgs struct {
 
 type Oboe interface {
 	UpdateSetting(arg SettingsUpdateArgs)
+	ReloadLocalSettings()
+	TouchSetting() bool
 	CheckSettingsTimeout()
 	GetSetting() *settings
 	RemoveSetting()
 	HasDefaultSetting() bool
-	SampleRequest(continued bool, url string, triggerTrace TriggerTraceMode, swState w3cfmt.SwTraceState) SampleDecision
+	SampleRequest(continued bool, url string, txnName string, triggerTrace TriggerTraceMode, swState w3cfmt.SwTraceState) SampleDecision
 	FlushRateCounts() *metrics.RateCountSummary
 	GetTriggerTraceToken() ([]byte, error)
 	RegisterOtelSampleRateMetrics(mp metric.MeterProvider) error
 }
 
 func NewOboe() Oboe {
-	return &oboe{}
+	return NewOboeWithStrategy(NewDefaultSamplingStrategy())
+}
+
+// NewOboeWithStrategy returns an Oboe which delegates the sampling decisions
+// to the given strategy. A nil strategy falls back to the default one.
+func NewOboeWithStrategy(strategy SamplingStrategy) Oboe {
+	if strategy == nil {
+		strategy = NewDefaultSamplingStrategy()
+	}
+	return &oboe{strategy: strategy}
 }
 
 type oboe struct {
 	settings atomic.Pointer[settings]
+	strategy SamplingStrategy
 }
 
 var _ Oboe = &oboe{}
@@ -118,6 +135,12 @@ func (o *oboe) RegisterOtelSampleRateMetrics(mp metric.MeterProvider) error {
 				obs.ObserveInt64(throughTraceCount, rateCounts.Through)
 				obs.ObserveInt64(triggeredTraceCount, rateCounts.TtTraced)
 			}
+			// The exhaustion count of the transactions having their own token
+			// bucket, on top of the service level one above.
+			for txn, limited := range metrics.TransactionRatesAggregator().FlushLimited() {
+				obs.ObserveInt64(tokenBucketExhaustionCount, limited,
+					metric.WithAttributes(attribute.String(constants.SwTransactionNameAttribute, txn)))
+			}
 			return nil
 		},
 		traceCount,
@@ -148,119 +171,68 @@ func (o *oboe) FlushRateCounts() *metrics.RateCountSummary {
 	}
 }
 
-// SampleRequest returns a SampleDecision based on inputs and state of various token buckets
-func (o *oboe) SampleRequest(continued bool, url string, triggerTrace TriggerTraceMode, swState w3cfmt.SwTraceState) SampleDecision {
+// SampleRequest returns a SampleDecision based on inputs and the current
+// settings, as decided by the sampling strategy
+func (o *oboe) SampleRequest(continued bool, url string, txnName string, triggerTrace TriggerTraceMode, swState w3cfmt.SwTraceState) SampleDecision {
 	setting := o.GetSetting()
 	if setting == nil {
 		return SampleDecision{false, 0, SampleSourceNone, false, TtSettingsNotAvailable, 0, 0, false}
 	}
 
-	var diceRolled, retval, doRateLimiting bool
-	sampleRate, flags, source := setting.mergeURLSetting(url)
-
-	// Choose an appropriate bucket
-	bucket := setting.bucket
-	switch triggerTrace {
-	case ModeRelaxedTriggerTrace:
-		bucket = setting.triggerTraceRelaxedBucket
-	case ModeStrictTriggerTrace:
-		bucket = setting.triggerTraceStrictBucket
-	}
-
-	if triggerTrace.Requested() && !continued {
-		sampled := (triggerTrace != ModeInvalidTriggerTrace) && (flags.TriggerTraceEnabled())
-		rsp := TtOK
-
-		ret := bucket.count(sampled, false, true, true)
-
-		if flags.TriggerTraceEnabled() && triggerTrace.Enabled() {
-			if !ret {
-				rsp = TtRateExceeded
-			}
-		} else if triggerTrace == ModeInvalidTriggerTrace {
-			rsp = ""
-		} else {
-			if !flags.Enabled() {
-				rsp = TtTracingDisabled
-			} else {
-				rsp = TtTriggerTracingDisabled
-			}
-		}
-		ttCap, ttRate := setting.getTokenBucketSetting(triggerTrace)
-		return SampleDecision{ret, -1, SampleSourceUnset, flags.Enabled(), rsp, ttRate, ttCap, diceRolled}
-	}
-
-	unsetBucketAndSampleKVs := false
-	if !continued {
-		// A new request
-		if flags&FlagSampleStart != 0 {
-			// roll the dice
-			diceRolled = true
-			retval = shouldSample(sampleRate)
-			if retval {
-				doRateLimiting = true
-			}
-		}
-	} else if swState.IsValid() {
-		if swState.Flags().IsSampled() {
-			if flags&FlagSampleThroughAlways != 0 {
-				// Conform to liboboe behavior; continue decision would result in a -1 value for the
-				// BucketCapacity, BucketRate, SampleRate and SampleSource KVs to indicate "unset".
-				unsetBucketAndSampleKVs = true
-				retval = true
-			} else if flags&FlagSampleThrough != 0 {
-				// roll the dice
-				diceRolled = true
-				retval = shouldSample(sampleRate)
-			}
-		} else {
-			retval = false
-		}
-	}
-
-	retval = bucket.count(retval, continued, doRateLimiting, false)
-
-	rsp := TtNotRequested
-	if triggerTrace.Requested() {
-		rsp = TtIgnored
-	}
-
-	var bucketCap, bucketRate float64
-	if unsetBucketAndSampleKVs {
-		bucketCap, bucketRate, sampleRate, source = -1, -1, -1, SampleSourceUnset
-	} else {
-		bucketCap, bucketRate = setting.getTokenBucketSetting(ModeTriggerTraceNotPresent)
-	}
-
-	return SampleDecision{
-		retval,
-		sampleRate,
-		source,
-		flags.Enabled(),
-		rsp,
-		bucketCap,
-		bucketRate,
-		diceRolled,
-	}
+	return o.strategy.Sample(newRequestSettings(setting, url, txnName), SamplingRequest{
+		Continued:    continued,
+		URL:          url,
+		Transaction:  txnName,
+		TriggerTrace: triggerTrace,
+		SwState:      swState,
+	})
 }
 
 func adjustSampleRate(rate int64) int {
 	if rate < 0 {
-		log.Debugf("Invalid sample rate: %d", rate)
+		logger.Debugf("Invalid sample rate: %d", rate)
 		return 0
 	}
 
 	if rate > maxSamplingRate {
-		log.Debugf("Invalid sample rate: %d", rate)
+		logger.Debugf("Invalid sample rate: %d", rate)
 		return maxSamplingRate
 	}
 	return int(rate)
 }
 
 func (o *oboe) UpdateSetting(arg SettingsUpdateArgs) {
+	ns := newSettingsFromArgs(arg)
+	ns.timestamp = time.Now()
+	if old := o.settings.Load(); old != nil {
+		ns.carryOverBuckets(old)
+	}
+	o.settings.Store(ns)
+}
+
+// ReloadLocalSettings merges the current remote settings with the local
+// sampling config and transaction settings again, e.g. after the config file
+// is reloaded. The timestamp and the token buckets are kept.
+func (o *oboe) ReloadLocalSettings() {
+	for {
+		s := o.settings.Load()
+		if s == nil {
+			return
+		}
+		ns := newSettingsFromArgs(s.args)
+		ns.timestamp = s.timestamp
+		ns.carryOverBuckets(s)
+		if o.settings.CompareAndSwap(s, ns) {
+			return
+		}
+	}
+}
+
+// newSettingsFromArgs returns the remote settings merged with the local ones
+func newSettingsFromArgs(arg SettingsUpdateArgs) *settings {
 	ns := newOboeSettings()
 
-	ns.timestamp = time.Now()
+	ns.args = arg
 	ns.source = SampleSourceDefault
 	ns.flags = flagStringToBin(arg.Flags)
 	ns.originalFlags = ns.flags
@@ -273,7 +245,25 @@ func (o *oboe) UpdateSetting(arg SettingsUpdateArgs) {
 	ns.triggerTraceStrictBucket.setRateCap(arg.TriggerStrictBucketRate, arg.TriggerStrictBucketCapacity)
 
 	ns.MergeLocalSetting()
-	o.settings.Store(ns)
+	ns.mergeTransactionSettings(config.GetTransactionFiltering())
+	return ns
+}
+
+// TouchSetting extends the current settings by resetting their timestamp,
+// keeping the token buckets as they are. It returns false if there are no
+// current settings.
+func (o *oboe) TouchSetting() bool {
+	for {
+		s := o.settings.Load()
+		if s == nil {
+			return false
+		}
+		ns := *s
+		ns.timestamp = time.Now()
+		if o.settings.CompareAndSwap(s, &ns) {
+			return true
+		}
+	}
 }
 
 // CheckSettingsTimeout checks and deletes expired settings
@@ -284,13 +274,13 @@ func (o *oboe) CheckSettingsTimeout() {
 func (o *oboe) checkSettingsTimeout() {
 	s := o.settings.Load()
 	if s == nil {
-		log.Debug("checkSettingsTimeout: No settings")
+		logger.Debug("checkSettingsTimeout: No settings")
 		return
 	}
 	e := s.timestamp.Add(s.ttl)
-	log.Debugf("checkSettingsTimeout: ttl: %s, timestamp: %s, boundary: %s", s.ttl, s.timestamp, e)
+	logger.Debugf("checkSettingsTimeout: ttl: %s, timestamp: %s, boundary: %s", s.ttl, s.timestamp, e)
 	if e.Before(time.Now()) {
-		log.Debugf("checkSettingsTimeout: ttl exceeded, expiring settings")
+		logger.Debugf("checkSettingsTimeout: ttl exceeded, expiring settings")
 		o.settings.Store(nil)
 	}
 }
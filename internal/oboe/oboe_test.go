@@ -18,6 +18,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/solarwinds/apm-go/internal/config"
 	"github.com/solarwinds/apm-go/internal/w3cfmt"
 	"github.com/stretchr/testify/require"
 )
@@ -88,7 +89,7 @@ func (settings SettingsUpdateArgs) WithLimitedTriggerTrace() SettingsUpdateArgs
 func TestOboeSampleRequestSettingsUnavailable(t *testing.T) {
 	ttMode := ModeTriggerTraceNotPresent
 	o := NewOboe()
-	dec := o.SampleRequest(false, "url", ttMode, sampledSwState)
+	dec := o.SampleRequest(false, "url", "", ttMode, sampledSwState)
 	expected := SampleDecision{
 		xTraceOptsRsp: "settings-not-available",
 	}
@@ -99,7 +100,7 @@ func TestOboeSampleRequestSettingsDisabled(t *testing.T) {
 	ttMode := ModeRelaxedTriggerTrace
 	o := NewOboe()
 	o.UpdateSetting(GetDefaultSettingForTest().WithDisabled())
-	dec := o.SampleRequest(false, "url", ttMode, sampledSwState)
+	dec := o.SampleRequest(false, "url", "", ttMode, sampledSwState)
 	expected := SampleDecision{
 		trace:         false,
 		rate:          -1,
@@ -116,7 +117,7 @@ func TestOboeSampleRequest(t *testing.T) {
 	ttMode := ModeTriggerTraceNotPresent
 	o := NewOboe()
 	o.UpdateSetting(GetDefaultSettingForTest())
-	dec := o.SampleRequest(false, "url", ttMode, sampledSwState)
+	dec := o.SampleRequest(false, "url", "", ttMode, sampledSwState)
 	expected := SampleDecision{
 		trace:         true,
 		rate:          1000000,
@@ -134,7 +135,7 @@ func TestOboeSampleRequestContinuedUnsampledSwState(t *testing.T) {
 	ttMode := ModeTriggerTraceNotPresent
 	o := NewOboe()
 	o.UpdateSetting(GetDefaultSettingForTest())
-	dec := o.SampleRequest(true, "url", ttMode, unsampledSwState)
+	dec := o.SampleRequest(true, "url", "", ttMode, unsampledSwState)
 	expected := SampleDecision{
 		trace:         false,
 		rate:          1000000,
@@ -152,7 +153,7 @@ func TestOboeSampleRequestNoTTGivenButReporterIsTTOnly(t *testing.T) {
 	ttMode := ModeTriggerTraceNotPresent
 	o := NewOboe()
 	o.UpdateSetting(GetDefaultSettingForTest().WithTriggerTraceOnly())
-	dec := o.SampleRequest(false, "url", ttMode, sampledSwState)
+	dec := o.SampleRequest(false, "url", "", ttMode, sampledSwState)
 	expected := SampleDecision{
 		trace:         false,
 		rate:          0,
@@ -170,7 +171,7 @@ func TestOboeSampleRequestUnsampledSwState(t *testing.T) {
 	ttMode := ModeTriggerTraceNotPresent
 	o := NewOboe()
 	o.UpdateSetting(GetDefaultSettingForTest())
-	dec := o.SampleRequest(false, "url", ttMode, unsampledSwState)
+	dec := o.SampleRequest(false, "url", "", ttMode, unsampledSwState)
 	expected := SampleDecision{
 		trace:         true,
 		rate:          1000000,
@@ -188,7 +189,7 @@ func TestOboeSampleRequestThrough(t *testing.T) {
 	ttMode := ModeTriggerTraceNotPresent
 	o := NewOboe()
 	o.UpdateSetting(GetDefaultSettingForTest().WithSampleThrough())
-	dec := o.SampleRequest(true, "url", ttMode, sampledSwState)
+	dec := o.SampleRequest(true, "url", "", ttMode, sampledSwState)
 	expected := SampleDecision{
 		trace:         true,
 		rate:          1000000,
@@ -206,7 +207,7 @@ func TestOboeSampleRequestThroughUnsampled(t *testing.T) {
 	ttMode := ModeTriggerTraceNotPresent
 	o := NewOboe()
 	o.UpdateSetting(GetDefaultSettingForTest().WithSampleThrough())
-	dec := o.SampleRequest(true, "url", ttMode, unsampledSwState)
+	dec := o.SampleRequest(true, "url", "", ttMode, unsampledSwState)
 	expected := SampleDecision{
 		trace:         false,
 		rate:          1000000,
@@ -226,7 +227,7 @@ func TestOboeSampleRequestRelaxedTT(t *testing.T) {
 	ttMode := ModeRelaxedTriggerTrace
 	o := NewOboe()
 	o.UpdateSetting(GetDefaultSettingForTest())
-	dec := o.SampleRequest(false, "url", ttMode, sampledSwState)
+	dec := o.SampleRequest(false, "url", "", ttMode, sampledSwState)
 	expected := SampleDecision{
 		trace:         true,
 		rate:          -1,
@@ -244,7 +245,7 @@ func TestOboeSampleRequestStrictTT(t *testing.T) {
 	ttMode := ModeStrictTriggerTrace
 	o := NewOboe()
 	o.UpdateSetting(GetDefaultSettingForTest())
-	dec := o.SampleRequest(false, "url", ttMode, sampledSwState)
+	dec := o.SampleRequest(false, "url", "", ttMode, sampledSwState)
 	expected := SampleDecision{
 		trace:         true,
 		rate:          -1,
@@ -262,7 +263,7 @@ func TestOboeSampleRequestRelaxedTTDisabled(t *testing.T) {
 	ttMode := ModeRelaxedTriggerTrace
 	o := NewOboe()
 	o.UpdateSetting(GetDefaultSettingForTest().WithNoTriggerTrace())
-	dec := o.SampleRequest(false, "url", ttMode, sampledSwState)
+	dec := o.SampleRequest(false, "url", "", ttMode, sampledSwState)
 	expected := SampleDecision{
 		trace:         false,
 		rate:          -1,
@@ -280,7 +281,7 @@ func TestOboeSampleRequestStrictTTDisabled(t *testing.T) {
 	ttMode := ModeStrictTriggerTrace
 	o := NewOboe()
 	o.UpdateSetting(GetDefaultSettingForTest().WithNoTriggerTrace())
-	dec := o.SampleRequest(false, "url", ttMode, sampledSwState)
+	dec := o.SampleRequest(false, "url", "", ttMode, sampledSwState)
 	expected := SampleDecision{
 		trace:         false,
 		rate:          -1,
@@ -298,7 +299,7 @@ func TestOboeSampleRequestRelaxedTTLimited(t *testing.T) {
 	ttMode := ModeRelaxedTriggerTrace
 	o := NewOboe()
 	o.UpdateSetting(GetDefaultSettingForTest().WithLimitedTriggerTrace())
-	dec := o.SampleRequest(false, "url", ttMode, sampledSwState)
+	dec := o.SampleRequest(false, "url", "", ttMode, sampledSwState)
 	// We expect the first TT to go through
 	expected := SampleDecision{
 		trace:         true,
@@ -311,7 +312,7 @@ func TestOboeSampleRequestRelaxedTTLimited(t *testing.T) {
 		diceRolled:    false,
 	}
 	require.Equal(t, expected, dec)
-	dec = o.SampleRequest(false, "url", ttMode, sampledSwState)
+	dec = o.SampleRequest(false, "url", "", ttMode, sampledSwState)
 	expected = SampleDecision{
 		trace:         false,
 		rate:          -1,
@@ -329,7 +330,7 @@ func TestOboeSampleRequestInvalidTT(t *testing.T) {
 	ttMode := ModeInvalidTriggerTrace
 	o := NewOboe()
 	o.UpdateSetting(GetDefaultSettingForTest())
-	dec := o.SampleRequest(false, "url", ttMode, sampledSwState)
+	dec := o.SampleRequest(false, "url", "", ttMode, sampledSwState)
 	expected := SampleDecision{
 		trace:         false,
 		rate:          -1,
@@ -374,3 +375,39 @@ func TestGetTokenBucketSetting(t *testing.T) {
 		}
 	}
 }
+
+func TestTouchSetting(t *testing.T) {
+	o := NewOboe()
+	require.False(t, o.TouchSetting())
+
+	o.UpdateSetting(GetDefaultSettingForTest())
+	before := o.GetSetting()
+	time.Sleep(time.Millisecond)
+	require.True(t, o.TouchSetting())
+	after := o.GetSetting()
+	require.True(t, after.timestamp.After(before.timestamp))
+	require.Equal(t, before.ttl, after.ttl)
+	require.Same(t, before.bucket, after.bucket)
+	require.Same(t, before.triggerTraceRelaxedBucket, after.triggerTraceRelaxedBucket)
+	require.Same(t, before.triggerTraceStrictBucket, after.triggerTraceStrictBucket)
+}
+
+func TestReloadLocalSettings(t *testing.T) {
+	t.Cleanup(func() { config.Load() })
+	config.Load()
+	o := NewOboe()
+	o.ReloadLocalSettings()
+	require.Nil(t, o.GetSetting())
+
+	o.UpdateSetting(GetDefaultSettingForTest())
+	before := o.GetSetting()
+	require.Equal(t, SampleSourceDefault, before.source)
+
+	config.Load(config.WithSampleRate(100))
+	o.ReloadLocalSettings()
+	after := o.GetSetting()
+	require.Equal(t, 100, after.value)
+	require.Equal(t, SampleSourceFile, after.source)
+	require.Equal(t, before.timestamp, after.timestamp)
+	require.Equal(t, before.ttl, after.ttl)
+}
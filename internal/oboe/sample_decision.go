@@ -28,6 +28,21 @@ type SampleDecision struct {
 	diceRolled bool
 }
 
+// NewSampleDecision returns the decision of a SamplingStrategy. bucket is the
+// token bucket the request is counted with, and xTraceOptsRsp the response to
+// the X-Trace-Options header, e.g. TtNotRequested.
+func NewSampleDecision(trace bool, s Settings, bucket TokenBucket, xTraceOptsRsp string) SampleDecision {
+	return SampleDecision{
+		trace:         trace,
+		rate:          s.SampleRate(),
+		source:        s.SampleSource(),
+		enabled:       s.Enabled(),
+		xTraceOptsRsp: xTraceOptsRsp,
+		bucketCap:     bucket.Capacity(),
+		bucketRate:    bucket.Rate(),
+	}
+}
+
 func (s SampleDecision) Trace() bool {
 	return s.trace
 }
@@ -0,0 +1,224 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oboe
+
+import "github.com/solarwinds/apm-go/internal/w3cfmt"
+
+// SamplingRequest holds the inputs of a sampling decision.
+type SamplingRequest struct {
+	// Continued is true if the request carries a valid upstream sw tracestate.
+	Continued bool
+	// URL is the request URL, used to look up the per-URL tracing mode.
+	URL string
+	// Transaction is the transaction name, used to look up the per-transaction
+	// sampling settings.
+	Transaction  string
+	TriggerTrace TriggerTraceMode
+	SwState      w3cfmt.SwTraceState
+}
+
+// SamplingStrategy makes the sampling decision for a request based on the
+// current settings.
+//
+// Implementations are expected to report the request to a token bucket (see
+// TokenBucket.Count) so that the RateCounts stay accurate, and to fill the
+// SampleRate, SampleSource and BucketCapacity/BucketRate of the decision as
+// the backend relies on them.
+type SamplingStrategy interface {
+	Sample(s Settings, req SamplingRequest) SampleDecision
+}
+
+// Settings is the read-only view of the settings of a request given to a
+// SamplingStrategy: the service level settings merged with those of the
+// transaction and the URL of the request.
+type Settings struct {
+	settings *settings
+	rate     int
+	flags    settingFlag
+	source   SampleSource
+	bucket   *tokenBucket
+}
+
+// newRequestSettings returns the settings of the request of url and txn
+func newRequestSettings(s *settings, url, txn string) Settings {
+	rate, flags, source, bucket := s.mergeRequestSetting(url, txn)
+	return Settings{settings: s, rate: rate, flags: flags, source: source, bucket: bucket}
+}
+
+// SampleRate returns the sample rate, out of 1,000,000
+func (s Settings) SampleRate() int {
+	return s.rate
+}
+
+// SampleSource returns where the sample rate comes from
+func (s Settings) SampleSource() SampleSource {
+	return s.source
+}
+
+// Enabled returns whether tracing is enabled
+func (s Settings) Enabled() bool {
+	return s.flags.Enabled()
+}
+
+// SampleStart returns whether the new requests are sampled
+func (s Settings) SampleStart() bool {
+	return s.flags&FlagSampleStart != 0
+}
+
+// SampleThrough returns whether the requests continuing a sampled trace are
+// sampled by the sample rate
+func (s Settings) SampleThrough() bool {
+	return s.flags&FlagSampleThrough != 0
+}
+
+// SampleThroughAlways returns whether the requests continuing a sampled trace
+// are always sampled
+func (s Settings) SampleThroughAlways() bool {
+	return s.flags&FlagSampleThroughAlways != 0
+}
+
+// TriggerTraceEnabled returns whether the trigger trace requests are traced
+func (s Settings) TriggerTraceEnabled() bool {
+	return s.flags.TriggerTraceEnabled()
+}
+
+// Bucket returns the token bucket which limits the requests of the trigger
+// trace mode: the relaxed or strict trigger trace bucket, or the bucket of
+// the request otherwise.
+func (s Settings) Bucket(mode TriggerTraceMode) TokenBucket {
+	if mode.Enabled() {
+		return TokenBucket{s.settings.getTokenBucket(mode)}
+	}
+	return TokenBucket{s.bucket}
+}
+
+// TokenBucket rate limits the sampled requests
+type TokenBucket struct {
+	bucket *tokenBucket
+}
+
+// Capacity returns the capacity of the bucket
+func (b TokenBucket) Capacity() float64 {
+	return b.bucket.capacity
+}
+
+// Rate returns the number of tokens added to the bucket per second
+func (b TokenBucket) Rate() float64 {
+	return b.bucket.ratePerSec
+}
+
+// Count reports the request to the bucket and the rate counts, and returns
+// whether it's traced. A sampled request consumes a token if rateLimit is
+// set, and isn't traced if there is none left. continued is whether the
+// request continues an upstream trace, and triggerTrace whether it's a
+// trigger trace request.
+func (b TokenBucket) Count(sampled, continued, rateLimit, triggerTrace bool) bool {
+	return b.bucket.count(sampled, continued, rateLimit, triggerTrace)
+}
+
+// NewDefaultSamplingStrategy returns the strategy driven by the setting flags,
+// the sample rate (dice roll) and the token buckets.
+func NewDefaultSamplingStrategy() SamplingStrategy {
+	return defaultStrategy{}
+}
+
+type defaultStrategy struct{}
+
+var _ SamplingStrategy = defaultStrategy{}
+
+func (defaultStrategy) Sample(setting Settings, req SamplingRequest) SampleDecision {
+	continued, triggerTrace, swState := req.Continued, req.TriggerTrace, req.SwState
+
+	var diceRolled, retval, doRateLimiting bool
+	sampleRate, flags, source := setting.rate, setting.flags, setting.source
+	// Choose an appropriate bucket
+	bucket := setting.Bucket(triggerTrace).bucket
+
+	if triggerTrace.Requested() && !continued {
+		sampled := (triggerTrace != ModeInvalidTriggerTrace) && (flags.TriggerTraceEnabled())
+		rsp := TtOK
+
+		ret := bucket.count(sampled, false, true, true)
+
+		if flags.TriggerTraceEnabled() && triggerTrace.Enabled() {
+			if !ret {
+				rsp = TtRateExceeded
+			}
+		} else if triggerTrace == ModeInvalidTriggerTrace {
+			rsp = ""
+		} else {
+			if !flags.Enabled() {
+				rsp = TtTracingDisabled
+			} else {
+				rsp = TtTriggerTracingDisabled
+			}
+		}
+		ttCap, ttRate := setting.settings.getTokenBucketSetting(triggerTrace)
+		return SampleDecision{ret, -1, SampleSourceUnset, flags.Enabled(), rsp, ttRate, ttCap, diceRolled}
+	}
+
+	unsetBucketAndSampleKVs := false
+	if !continued {
+		// A new request
+		if flags&FlagSampleStart != 0 {
+			// roll the dice
+			diceRolled = true
+			retval = shouldSample(sampleRate)
+			if retval {
+				doRateLimiting = true
+			}
+		}
+	} else if swState.IsValid() {
+		if swState.Flags().IsSampled() {
+			if flags&FlagSampleThroughAlways != 0 {
+				// Conform to liboboe behavior; continue decision would result in a -1 value for the
+				// BucketCapacity, BucketRate, SampleRate and SampleSource KVs to indicate "unset".
+				unsetBucketAndSampleKVs = true
+				retval = true
+			} else if flags&FlagSampleThrough != 0 {
+				// roll the dice
+				diceRolled = true
+				retval = shouldSample(sampleRate)
+			}
+		} else {
+			retval = false
+		}
+	}
+
+	retval = bucket.count(retval, continued, doRateLimiting, false)
+
+	rsp := TtNotRequested
+	if triggerTrace.Requested() {
+		rsp = TtIgnored
+	}
+
+	var bucketCap, bucketRate float64
+	if unsetBucketAndSampleKVs {
+		bucketCap, bucketRate, sampleRate, source = -1, -1, -1, SampleSourceUnset
+	} else {
+		bucketCap, bucketRate = bucket.capacity, bucket.ratePerSec
+	}
+
+	return SampleDecision{
+		retval,
+		sampleRate,
+		source,
+		flags.Enabled(),
+		rsp,
+		bucketCap,
+		bucketRate,
+		diceRolled,
+	}
+}
@@ -0,0 +1,89 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oboe
+
+import (
+	"testing"
+
+	"github.com/solarwinds/apm-go/internal/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+// parentBasedStrategy only follows the upstream decision, counting through
+// the regular bucket.
+type parentBasedStrategy struct{}
+
+func (parentBasedStrategy) Sample(s Settings, req SamplingRequest) SampleDecision {
+	sampled := req.Continued && req.SwState.IsValid() && req.SwState.Flags().IsSampled()
+	bucket := s.Bucket(ModeTriggerTraceNotPresent)
+	sampled = bucket.Count(sampled, req.Continued, false, false)
+	return NewSampleDecision(sampled, s, bucket, TtNotRequested)
+}
+
+func TestNewOboeWithStrategy(t *testing.T) {
+	o := NewOboeWithStrategy(parentBasedStrategy{})
+	o.UpdateSetting(GetDefaultSettingForTest())
+	metrics.RatesAggregator().FlushRateCounts()
+
+	dec := o.SampleRequest(false, "", "", ModeTriggerTraceNotPresent, unsampledSwState)
+	require.False(t, dec.Trace())
+	require.Equal(t, 1000000, dec.SampleRate())
+	require.Equal(t, SampleSourceDefault, dec.SampleSource())
+	require.Equal(t, float64(1000000), dec.BucketCapacity())
+
+	dec = o.SampleRequest(true, "", "", ModeTriggerTraceNotPresent, sampledSwState)
+	require.True(t, dec.Trace())
+
+	counts := o.FlushRateCounts()
+	require.Equal(t, int64(2), counts.Requested)
+	require.Equal(t, int64(1), counts.Sampled)
+	require.Equal(t, int64(1), counts.Through)
+	require.Equal(t, int64(1), counts.Traced)
+}
+
+func TestNewOboeWithNilStrategy(t *testing.T) {
+	o := NewOboeWithStrategy(nil)
+	o.UpdateSetting(GetDefaultSettingForTest())
+	dec := o.SampleRequest(false, "", "", ModeTriggerTraceNotPresent, unsampledSwState)
+	require.True(t, dec.Trace())
+	require.Equal(t, TtNotRequested, dec.XTraceOptsRsp())
+}
+
+func TestGetTokenBucket(t *testing.T) {
+	s := newOboeSettings()
+	require.Same(t, s.bucket, s.getTokenBucket(ModeTriggerTraceNotPresent))
+	require.Same(t, s.bucket, s.getTokenBucket(ModeInvalidTriggerTrace))
+	require.Same(t, s.triggerTraceRelaxedBucket, s.getTokenBucket(ModeRelaxedTriggerTrace))
+	require.Same(t, s.triggerTraceStrictBucket, s.getTokenBucket(ModeStrictTriggerTrace))
+}
+
+func TestRequestSettings(t *testing.T) {
+	o := NewOboe().(*oboe)
+	o.UpdateSetting(GetDefaultSettingForTest())
+	s := newRequestSettings(o.GetSetting(), "", "")
+	require.Equal(t, 1000000, s.SampleRate())
+	require.Equal(t, SampleSourceDefault, s.SampleSource())
+	require.True(t, s.Enabled())
+	require.True(t, s.SampleStart())
+	require.False(t, s.SampleThrough())
+	require.True(t, s.SampleThroughAlways())
+	require.True(t, s.TriggerTraceEnabled())
+	require.Same(t, s.settings.bucket, s.Bucket(ModeTriggerTraceNotPresent).bucket)
+	require.Same(t, s.settings.bucket, s.Bucket(ModeInvalidTriggerTrace).bucket)
+	require.Same(t, s.settings.triggerTraceRelaxedBucket, s.Bucket(ModeRelaxedTriggerTrace).bucket)
+	require.Same(t, s.settings.triggerTraceStrictBucket, s.Bucket(ModeStrictTriggerTrace).bucket)
+	require.Equal(t, s.settings.bucket.capacity, s.Bucket(ModeTriggerTraceNotPresent).Capacity())
+	require.Equal(t, s.settings.bucket.ratePerSec, s.Bucket(ModeTriggerTraceNotPresent).Rate())
+}
@@ -18,7 +18,6 @@ import (
 	"time"
 
 	"github.com/solarwinds/apm-go/internal/config"
-	"github.com/solarwinds/apm-go/internal/log"
 )
 
 type settings struct {
@@ -37,6 +36,11 @@ type settings struct {
 	bucket                    *tokenBucket
 	triggerTraceRelaxedBucket *tokenBucket
 	triggerTraceStrictBucket  *tokenBucket
+	// the settings of the transactions configured locally, keyed by the
+	// transaction name
+	transactions map[string]*transactionSetting
+	// the remote settings these settings are built from
+	args SettingsUpdateArgs
 }
 
 func (s *settings) hasOverrideFlag() bool {
@@ -80,26 +84,61 @@ func (s *settings) MergeLocalSetting() {
 	}
 }
 
-// mergeURLSetting merges the service level setting (merged from remote and local
-// settings) and the per-URL sampling flags, if any.
-func (s *settings) mergeURLSetting(url string) (int, settingFlag, SampleSource) {
+// mergeRequestSetting merges the service level setting (merged from remote and
+// local settings), the per-transaction settings and the per-URL sampling flags,
+// if any. It returns the sample rate, flags, sample source and the token bucket
+// which applies to the request.
+func (s *settings) mergeRequestSetting(url, txn string) (int, settingFlag, SampleSource, *tokenBucket) {
+	value, flags, source, bucket := s.value, s.flags, s.source, s.bucket
+	if ts, ok := s.transactions[txn]; ok {
+		value, flags, source, bucket = ts.value, ts.flags, ts.source, ts.bucket
+	}
+
 	if url == "" {
-		return s.value, s.flags, s.source
+		return value, flags, source, bucket
 	}
 
-	urlTracingMode := urls.GetTracingMode(url)
+	urlTracingMode := urls.Load().GetTracingMode(url)
 	if urlTracingMode.isUnknown() {
-		return s.value, s.flags, s.source
+		return value, flags, source, bucket
 	}
 
-	flags := urlTracingMode.toFlags()
-	source := SampleSourceFile
+	flags = urlTracingMode.toFlags()
+	source = SampleSourceFile
 
 	if s.hasOverrideFlag() {
 		flags &= s.originalFlags
 	}
 
-	return s.value, flags, source
+	return value, flags, source, bucket
+}
+
+// carryOverBuckets carries over the state of the token buckets of the settings
+// being replaced, so that refreshing the settings doesn't reset them.
+func (s *settings) carryOverBuckets(old *settings) {
+	s.bucket.carryOver(old.bucket)
+	s.triggerTraceRelaxedBucket.carryOver(old.triggerTraceRelaxedBucket)
+	s.triggerTraceStrictBucket.carryOver(old.triggerTraceStrictBucket)
+	for name, ts := range s.transactions {
+		ots, ok := old.transactions[name]
+		// Only the transactions' own buckets, the shared one is carried over above
+		if ok && ts.bucket != s.bucket && ots.bucket != old.bucket {
+			ts.bucket.carryOver(ots.bucket)
+		}
+	}
+}
+
+// getTokenBucket returns the token bucket which limits requests of the given
+// trigger trace mode.
+func (s *settings) getTokenBucket(ttMode TriggerTraceMode) *tokenBucket {
+	switch ttMode {
+	case ModeRelaxedTriggerTrace:
+		return s.triggerTraceRelaxedBucket
+	case ModeStrictTriggerTrace:
+		return s.triggerTraceStrictBucket
+	default:
+		return s.bucket
+	}
 }
 
 func (s *settings) getTokenBucketSetting(ttMode TriggerTraceMode) (capacity float64, rate float64) {
@@ -113,7 +152,7 @@ func (s *settings) getTokenBucketSetting(ttMode TriggerTraceMode) (capacity floa
 	case ModeTriggerTraceNotPresent, ModeInvalidTriggerTrace:
 		bucket = s.bucket
 	default:
-		log.Warningf("Could not determine token bucket setting for invalid TriggerTraceMode: %#v", ttMode)
+		logger.Warningf("Could not determine token bucket setting for invalid TriggerTraceMode: %#v", ttMode)
 		return 0, 0
 	}
 
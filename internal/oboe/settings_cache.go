@@ -0,0 +1,106 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oboe
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// errSettingsCacheExpired is returned when the cached settings are past their TTL
+var errSettingsCacheExpired = errors.New("cached settings expired")
+
+// settingsCache persists the last settings fetched from the settings API, so
+// that they can be used on startup before the first fetch succeeds.
+type settingsCache struct {
+	path        string
+	serviceName string
+}
+
+// cachedSettings is the content of the cache file
+type cachedSettings struct {
+	ServiceName string        `json:"serviceName"`
+	FetchedAt   time.Time     `json:"fetchedAt"`
+	Settings    *httpSettings `json:"settings"`
+}
+
+func newSettingsCache(path, serviceName string) *settingsCache {
+	if path == "" {
+		return nil
+	}
+	return &settingsCache{path: path, serviceName: serviceName}
+}
+
+// save writes the settings to the cache file. The file is replaced atomically
+// and only readable by the owner as it contains the trigger trace signature key.
+func (c *settingsCache) save(s *httpSettings, fetchedAt time.Time) error {
+	data, err := json.Marshal(cachedSettings{
+		ServiceName: c.serviceName,
+		FetchedAt:   fetchedAt,
+		Settings:    s,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal settings: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create settings cache file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write settings cache file: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write settings cache file: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to replace settings cache file: %w", err)
+	}
+	return nil
+}
+
+// load reads the settings from the cache file and returns the update arguments
+// with the TTL reduced by the time elapsed since they were fetched.
+func (c *settingsCache) load(now time.Time) (SettingsUpdateArgs, error) {
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return SettingsUpdateArgs{}, fmt.Errorf("failed to read settings cache file: %w", err)
+	}
+
+	var cached cachedSettings
+	if err = json.Unmarshal(data, &cached); err != nil {
+		return SettingsUpdateArgs{}, fmt.Errorf("failed to unmarshal settings cache file: %w", err)
+	}
+	if cached.ServiceName != c.serviceName {
+		return SettingsUpdateArgs{}, fmt.Errorf("cached settings are for service %q", cached.ServiceName)
+	}
+	if cached.Settings == nil || cached.Settings.Arguments == nil {
+		return SettingsUpdateArgs{}, errors.New("cached settings are incomplete")
+	}
+
+	args := cached.Settings.ToSettingsUpdateArgs()
+	args.Ttl -= now.Sub(cached.FetchedAt)
+	if args.Ttl <= 0 {
+		return SettingsUpdateArgs{}, errSettingsCacheExpired
+	}
+	return args, nil
+}
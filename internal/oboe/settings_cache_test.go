@@ -0,0 +1,148 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oboe
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testHttpSettings() *httpSettings {
+	return &httpSettings{
+		Flags:     "SAMPLE_START,SAMPLE_THROUGH_ALWAYS,TRIGGER_TRACE",
+		Value:     500000,
+		Ttl:       120,
+		Timestamp: time.Now().Unix(),
+		Arguments: &httpSettingArguments{
+			BucketCapacity:               2,
+			BucketRate:                   1,
+			TriggerRelaxedBucketCapacity: 20,
+			TriggerRelaxedBucketRate:     1,
+			TriggerStrictBucketCapacity:  6,
+			TriggerStrictBucketRate:      0.1,
+			TriggerToken:                 "token",
+		},
+	}
+}
+
+func TestSettingsCacheSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	c := newSettingsCache(path, "my-service")
+	fetchedAt := time.Now()
+	require.NoError(t, c.save(testHttpSettings(), fetchedAt))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	args, err := c.load(fetchedAt.Add(30 * time.Second))
+	require.NoError(t, err)
+	expected := testHttpSettings().ToSettingsUpdateArgs()
+	expected.Ttl = 90 * time.Second
+	require.Equal(t, expected, args)
+
+	// Saving again replaces the file
+	s := testHttpSettings()
+	s.Value = 1000
+	require.NoError(t, c.save(s, fetchedAt))
+	args, err = c.load(fetchedAt)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), args.Value)
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func TestSettingsCacheLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "settings.json")
+	fetchedAt := time.Now()
+
+	_, err := newSettingsCache(path, "my-service").load(fetchedAt)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, newSettingsCache(path, "my-service").save(testHttpSettings(), fetchedAt))
+	_, err = newSettingsCache(path, "my-service").load(fetchedAt.Add(2 * time.Minute))
+	require.ErrorIs(t, err, errSettingsCacheExpired)
+
+	_, err = newSettingsCache(path, "other-service").load(fetchedAt)
+	require.ErrorContains(t, err, `cached settings are for service "my-service"`)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"serviceName":"my-service"}`), 0600))
+	_, err = newSettingsCache(path, "my-service").load(fetchedAt)
+	require.ErrorContains(t, err, "incomplete")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{`), 0600))
+	_, err = newSettingsCache(path, "my-service").load(fetchedAt)
+	require.ErrorContains(t, err, "failed to unmarshal")
+
+	require.Nil(t, newSettingsCache("", "my-service"))
+}
+
+func TestSettingsUpdaterUsesCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "settings.json")
+	cache := newSettingsCache(path, "my-service")
+	require.NoError(t, cache.save(testHttpSettings(), time.Now().Add(-time.Minute)))
+
+	o := NewOboe()
+	su := &settingsUpdater{
+		updateInterval:   time.Hour,
+		ttlCheckInterval: time.Hour,
+		oboe:             o,
+		settingsService:  newSettingsService(server.URL, "my-service", "", "token"),
+		settingsCache:    cache,
+	}
+	stop := su.Start(context.Background())
+	defer stop()
+
+	// The cached settings are available right after Start, even though the
+	// collector is unreachable.
+	require.True(t, o.HasDefaultSetting())
+	s := o.GetSetting()
+	require.Equal(t, 500000, s.value)
+	require.InDelta(t, time.Minute.Seconds(), s.ttl.Seconds(), 1)
+	require.Equal(t, []byte("token"), s.TriggerToken)
+}
+
+func TestSettingsUpdaterSavesCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	su := &settingsUpdater{
+		oboe:          NewOboe(),
+		settingsCache: newSettingsCache(path, "my-service"),
+	}
+	su.saveCachedSettings(testHttpSettings())
+
+	args, err := su.settingsCache.load(time.Now())
+	require.NoError(t, err)
+	require.Equal(t, int64(500000), args.Value)
+
+	// No-op without a cache
+	su = &settingsUpdater{oboe: NewOboe()}
+	su.saveCachedSettings(testHttpSettings())
+	su.loadCachedSettings()
+	require.False(t, su.oboe.HasDefaultSetting())
+}
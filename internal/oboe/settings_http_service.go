@@ -17,15 +17,16 @@ package oboe
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/solarwinds/apm-go/internal/config"
-	"github.com/solarwinds/apm-go/internal/log"
 	"github.com/solarwinds/apm-go/internal/proxy"
 )
 
@@ -33,12 +34,21 @@ const (
 	defaultTimeout = 10 * time.Second
 )
 
+// errSettingsNotModified is returned when the settings service reports that
+// the settings didn't change since the last fetch.
+var errSettingsNotModified = errors.New("settings not modified")
+
 type settingsService struct {
 	baseURL     string
 	serviceName string
 	hostName    string
 	bearerToken string
 	client      *http.Client
+
+	// The validators of the last settings fetched, sent with the next request
+	// to only get the settings back if they changed.
+	etag         string
+	lastModified string
 }
 
 func newSettingsService(baseURL, serviceName, hostName, bearerToken string) *settingsService {
@@ -71,13 +81,14 @@ func newSettingsService(baseURL, serviceName, hostName, bearerToken string) *set
 
 func (s *settingsService) getSettings(ctx context.Context) (*httpSettings, error) {
 	requestUrl := s.buildURL()
-	log.Debugf("Fetching settings from URL: %s", requestUrl)
+	settingsLogger.Debugf("Fetching settings from URL: %s", requestUrl)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestUrl, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	s.setAuthHeaders(req)
+	s.setConditionalHeaders(req)
 
 	resp, err := s.client.Do(req)
 	if err != nil {
@@ -85,7 +96,7 @@ func (s *settingsService) getSettings(ctx context.Context) (*httpSettings, error
 	}
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
-			log.Warningf("failed to close response body querying settings: %v", closeErr)
+			settingsLogger.Warningf("failed to close response body querying settings: %v", closeErr)
 		}
 	}()
 
@@ -93,9 +104,19 @@ func (s *settingsService) getSettings(ctx context.Context) (*httpSettings, error
 		return nil, config.ErrInvalidServiceKey
 	}
 
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, errSettingsNotModified
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		err = fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				return nil, &retryAfterError{err: err, delay: delay}
+			}
+		}
+		return nil, err
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -108,9 +129,45 @@ func (s *settingsService) getSettings(ctx context.Context) (*httpSettings, error
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
+	s.etag = resp.Header.Get("ETag")
+	s.lastModified = resp.Header.Get("Last-Modified")
 	return &settings, nil
 }
 
+// retryAfterError is returned when the settings service asks the client to
+// retry after a given delay.
+type retryAfterError struct {
+	err   error
+	delay time.Duration
+}
+
+func (e *retryAfterError) Error() string {
+	return fmt.Sprintf("%s (retry after %s)", e.err, e.delay)
+}
+
+func (e *retryAfterError) Unwrap() error {
+	return e.err
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
+
 func (s *settingsService) buildURL() string {
 	return fmt.Sprintf("%s/v1/settings/%s/%s",
 		strings.TrimSuffix(s.baseURL, "/"),
@@ -118,6 +175,22 @@ func (s *settingsService) buildURL() string {
 		url.PathEscape(s.hostName))
 }
 
+// setConditionalHeaders makes the request conditional on the settings having
+// changed since the last fetch, if the settings service returned validators.
+func (s *settingsService) setConditionalHeaders(req *http.Request) {
+	if s.etag != "" {
+		req.Header.Set("If-None-Match", s.etag)
+	} else if s.lastModified != "" {
+		req.Header.Set("If-Modified-Since", s.lastModified)
+	}
+}
+
+// resetValidators makes the next request unconditional
+func (s *settingsService) resetValidators() {
+	s.etag = ""
+	s.lastModified = ""
+}
+
 func (s *settingsService) setAuthHeaders(req *http.Request) {
 	req.Header.Set("Authorization", "Bearer "+s.bearerToken)
 	req.Header.Set("Accept", "application/json")
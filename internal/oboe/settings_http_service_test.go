@@ -17,6 +17,7 @@ package oboe
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -187,3 +188,110 @@ func TestGetSettings_EmptyResponse(t *testing.T) {
 	assert.Equal(t, "", settings.Flags)
 	assert.Equal(t, int64(0), settings.Value)
 }
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{" 0 ", 0, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{"Wed, 01 Jan 2025 00:00:30 GMT", 30 * time.Second, true},
+		{"Tue, 31 Dec 2024 23:59:00 GMT", 0, true},
+	} {
+		d, ok := parseRetryAfter(tc.value, now)
+		require.Equal(t, tc.ok, ok, tc.value)
+		require.Equal(t, tc.expected, d, tc.value)
+	}
+}
+
+func TestGetSettingsRetryAfter(t *testing.T) {
+	status := http.StatusTooManyRequests
+	retryAfter := "5"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if retryAfter != "" {
+			w.Header().Set("Retry-After", retryAfter)
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	s := newSettingsService(server.URL, "svc", "", "token")
+
+	_, err := s.getSettings(context.Background())
+	var rae *retryAfterError
+	require.ErrorAs(t, err, &rae)
+	require.Equal(t, 5*time.Second, rae.delay)
+	require.ErrorContains(t, err, "unexpected status code 429")
+
+	status = http.StatusServiceUnavailable
+	_, err = s.getSettings(context.Background())
+	require.ErrorAs(t, err, &rae)
+
+	// Retry-After is only honored on 429 and 503
+	status = http.StatusInternalServerError
+	_, err = s.getSettings(context.Background())
+	require.False(t, errors.As(err, &rae))
+
+	status, retryAfter = http.StatusServiceUnavailable, ""
+	_, err = s.getSettings(context.Background())
+	require.False(t, errors.As(err, &rae))
+}
+
+func TestGetSettingsConditional(t *testing.T) {
+	var lastHeaders http.Header
+	etag, lastModified := `"v1"`, ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastHeaders = r.Header.Clone()
+		if etag != "" && r.Header.Get("If-None-Match") == etag ||
+			lastModified != "" && r.Header.Get("If-Modified-Since") == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if etag != "" {
+			w.Header().Set("ETag", etag)
+		}
+		if lastModified != "" {
+			w.Header().Set("Last-Modified", lastModified)
+		}
+		_, _ = w.Write([]byte(`{"flags":"SAMPLE_START","value":1000000,"ttl":120,"arguments":{}}`))
+	}))
+	defer server.Close()
+	s := newSettingsService(server.URL, "svc", "", "token")
+
+	settings, err := s.getSettings(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, settings)
+	require.Empty(t, lastHeaders.Get("If-None-Match"))
+
+	_, err = s.getSettings(context.Background())
+	require.ErrorIs(t, err, errSettingsNotModified)
+	require.Equal(t, `"v1"`, lastHeaders.Get("If-None-Match"))
+
+	// A changed ETag returns the settings
+	etag = `"v2"`
+	settings, err = s.getSettings(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, settings)
+	require.Equal(t, `"v2"`, s.etag)
+
+	// Unconditional request after a reset
+	s.resetValidators()
+	_, err = s.getSettings(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, lastHeaders.Get("If-None-Match"))
+
+	// Last-Modified is used when there is no ETag
+	etag, lastModified = "", "Wed, 01 Jan 2025 00:00:00 GMT"
+	s.resetValidators()
+	_, err = s.getSettings(context.Background())
+	require.NoError(t, err)
+	_, err = s.getSettings(context.Background())
+	require.ErrorIs(t, err, errSettingsNotModified)
+	require.Equal(t, lastModified, lastHeaders.Get("If-Modified-Since"))
+	require.Empty(t, lastHeaders.Get("If-None-Match"))
+}
@@ -17,10 +17,14 @@ package oboe
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"sync"
 	"time"
 
 	"github.com/solarwinds/apm-go/internal/config"
 	"github.com/solarwinds/apm-go/internal/log"
+	"github.com/solarwinds/apm-go/internal/rand"
 )
 
 const (
@@ -28,20 +32,52 @@ const (
 	defaultSettingsTTLCheckInterval = 30 * time.Second
 )
 
+// settingsLogger prints the logs of the retrieval of the sampling settings
+var settingsLogger = log.WithComponent("settings")
+
 type settingsUpdater struct {
 	updateInterval   time.Duration
 	ttlCheckInterval time.Duration
-	oboe             Oboe
-	settingsService  *settingsService
+	// the first fetch is delayed randomly by up to this duration
+	startupJitter time.Duration
+	// the ratio of the update interval by which each update is randomly
+	// advanced or delayed
+	updateJitterRatio float64
+	oboe              Oboe
+	settingsService   *settingsService
+	// nil if the settings cache is not configured
+	settingsCache *settingsCache
+	backoff       backoff
+	// the number of consecutive failed fetches. It's only accessed by the
+	// running getAndUpdateSettings.
+	failures int
+	// the last settings fetched, saved again to the cache when the settings
+	// service reports they are not modified. It's only accessed by the running
+	// getAndUpdateSettings.
+	lastSettings *httpSettings
+
+	statusMut sync.RWMutex
+	status    SettingsUpdaterStatus
+}
+
+// SettingsUpdaterStatus reports the outcome of the settings fetches.
+type SettingsUpdaterStatus struct {
+	// The time of the last fetch, either successful or not
+	LastFetch time.Time
+	// The time of the last successful fetch
+	LastSuccess time.Time
+	// The error of the last fetch, nil if it succeeded
+	LastError error
 }
 
 type SettingsUpdater interface {
 	Start(ctx context.Context) func()
+	Status() SettingsUpdaterStatus
 }
 
 func NewSettingsUpdater(o Oboe, serviceName string) (SettingsUpdater, error) {
 	if !config.GetEnabled() {
-		log.Info("SolarWinds Observability APM agent is disabled. Settings are not polled from the server.")
+		settingsLogger.Info("SolarWinds Observability APM agent is disabled. Settings are not polled from the server.")
 		return newNullSettingsUpdater(), nil
 	}
 
@@ -53,23 +89,30 @@ func NewSettingsUpdater(o Oboe, serviceName string) (SettingsUpdater, error) {
 	settingsUrl := config.SettingsURL()
 
 	return &settingsUpdater{
-		updateInterval:   defaultSettingsUpdateInterval,
-		ttlCheckInterval: defaultSettingsTTLCheckInterval,
-		oboe:             o,
-		settingsService:  newSettingsService(settingsUrl, serviceName, "", parsedServiceKey.Token),
+		updateInterval:    defaultSettingsUpdateInterval,
+		ttlCheckInterval:  defaultSettingsTTLCheckInterval,
+		startupJitter:     config.GetSettingsStartupJitter(),
+		updateJitterRatio: config.GetSettingsUpdateJitter(),
+		oboe:              o,
+		settingsService:   newSettingsService(settingsUrl, serviceName, "", parsedServiceKey.Token),
+		settingsCache:     newSettingsCache(config.GetSettingsCacheFile(), serviceName),
+		backoff:           newBackoff(config.ReporterOpts()),
 	}, nil
 }
 
 func (su *settingsUpdater) Start(ctx context.Context) func() {
+	// Load the cached settings synchronously so that requests can be sampled
+	// right away, before the first fetch completes.
+	su.loadCachedSettings()
 	ctx, cancel := context.WithCancel(ctx)
 	go su.run(ctx, cancel)
 	return cancel
 }
 
 func (su *settingsUpdater) run(ctx context.Context, cancel context.CancelFunc) {
-	defer log.Info("http settings updater exiting.")
+	defer settingsLogger.Info("http settings updater exiting.")
 
-	updateTimer := time.NewTimer(0) // Execute immediately on startup
+	updateTimer := time.NewTimer(jitter(su.startupJitter)) // Execute shortly after startup
 	ttlTimer := time.NewTimer(su.ttlCheckInterval)
 	defer func() {
 		updateTimer.Stop()
@@ -85,7 +128,7 @@ func (su *settingsUpdater) run(ctx context.Context, cancel context.CancelFunc) {
 	for {
 		select {
 		case <-ctx.Done():
-			log.Infof("http settings updater requested to stop: %v", ctx.Err())
+			settingsLogger.Infof("http settings updater requested to stop: %v", ctx.Err())
 			return
 		case <-updateTimer.C:
 			// Only start new execution if previous one has completed
@@ -100,7 +143,7 @@ func (su *settingsUpdater) run(ctx context.Context, cancel context.CancelFunc) {
 			default:
 				// Previous execution still running, skip this tick
 			}
-			updateTimer.Reset(su.updateInterval)
+			updateTimer.Reset(su.nextUpdateInterval())
 		case <-ttlTimer.C:
 			// Only start new execution if previous one has completed
 			select {
@@ -114,20 +157,98 @@ func (su *settingsUpdater) run(ctx context.Context, cancel context.CancelFunc) {
 	}
 }
 
+// getAndUpdateSettings fetches the settings and updates oboe. Failed fetches
+// are retried with an exponential backoff, or after the delay requested by the
+// settings service, up to backoff.maxRetries times. It returns false if the
+// service key is invalid and polling should stop.
 func (su *settingsUpdater) getAndUpdateSettings(ctx context.Context, ready chan bool) bool {
 	defer func() { ready <- true }()
 
-	settings, err := su.getSettings(ctx)
+	for retry := 1; ; retry++ {
+		if !su.oboe.HasDefaultSetting() {
+			// The settings expired or were never fetched, a 304 response
+			// couldn't be applied.
+			su.settingsService.resetValidators()
+		}
+		settings, err := su.getSettings(ctx)
+		if errors.Is(err, errSettingsNotModified) {
+			if su.oboe.TouchSetting() {
+				su.setStatus(nil)
+				su.resetFailures()
+				settingsLogger.Debug("Sampling settings not modified, extending the current settings")
+				su.saveCachedSettings(su.lastSettings)
+				return true
+			}
+			// The settings expired in the meantime, they are fetched
+			// unconditionally on retry.
+			err = fmt.Errorf("%w, but the current settings expired", err)
+		}
+		su.setStatus(err)
+		if err == nil {
+			su.resetFailures()
+			settingsLogger.Debugf("Retrieved sampling settings: %+v", settings)
+			su.oboe.UpdateSetting(settings.ToSettingsUpdateArgs())
+			su.lastSettings = settings
+			su.saveCachedSettings(settings)
+			return true
+		} else if errors.Is(err, config.ErrInvalidServiceKey) {
+			settingsLogger.Errorf("invalid service key, stopping settings updater: %v", err)
+			return false
+		} else if ctx.Err() != nil {
+			return true
+		}
+
+		su.failures++
+		if su.backoff.shouldWarn(su.failures) {
+			settingsLogger.Limited("retrieve failed").Warningf("failed to retrieve sampling settings (%d consecutive failures): %v", su.failures, err)
+		} else {
+			settingsLogger.Debugf("failed to retrieve sampling settings (%d consecutive failures): %v", su.failures, err)
+		}
+
+		if retry > su.backoff.maxRetries {
+			// Wait for the next scheduled update
+			return true
+		}
+		delay := su.backoff.delay(retry)
+		var retryAfter *retryAfterError
+		if errors.As(err, &retryAfter) {
+			// Capped so that a long Retry-After doesn't hold the updater
+			// while the current settings expire
+			delay = min(retryAfter.delay, su.backoff.max)
+		}
+		settingsLogger.Debugf("retrying to retrieve sampling settings in %s", delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return true
+		case <-timer.C:
+		}
+	}
+}
+
+func (su *settingsUpdater) resetFailures() {
+	if su.failures > 0 {
+		settingsLogger.Infof("Retrieved sampling settings after %d failed attempts", su.failures)
+		su.failures = 0
+	}
+}
+
+func (su *settingsUpdater) Status() SettingsUpdaterStatus {
+	su.statusMut.RLock()
+	defer su.statusMut.RUnlock()
+	return su.status
+}
+
+func (su *settingsUpdater) setStatus(err error) {
+	su.statusMut.Lock()
+	defer su.statusMut.Unlock()
+	now := time.Now()
+	su.status.LastFetch = now
+	su.status.LastError = err
 	if err == nil {
-		log.Debugf("Retrieved sampling settings: %+v", settings)
-		su.oboe.UpdateSetting(settings.ToSettingsUpdateArgs())
-		return true
-	} else if errors.Is(err, config.ErrInvalidServiceKey) {
-		log.Errorf("invalid service key, stopping settings updater: %v", err)
-		return false
-	} else {
-		log.Warningf("failed to retrieve sampling settings: %v", err)
-		return true
+		su.status.LastSuccess = now
 	}
 }
 
@@ -135,11 +256,52 @@ func (su *settingsUpdater) getSettings(ctx context.Context) (*httpSettings, erro
 	return su.settingsService.getSettings(ctx)
 }
 
+func (su *settingsUpdater) loadCachedSettings() {
+	if su.settingsCache == nil {
+		return
+	}
+	args, err := su.settingsCache.load(time.Now())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			settingsLogger.Debugf("no cached sampling settings found: %v", err)
+		} else {
+			settingsLogger.Infof("ignoring cached sampling settings: %v", err)
+		}
+		return
+	}
+	settingsLogger.Infof("Loaded cached sampling settings, expiring in %s", args.Ttl)
+	su.oboe.UpdateSetting(args)
+}
+
+func (su *settingsUpdater) saveCachedSettings(settings *httpSettings) {
+	if su.settingsCache == nil || settings == nil {
+		return
+	}
+	if err := su.settingsCache.save(settings, time.Now()); err != nil {
+		settingsLogger.Limited("cache failed").Warningf("failed to cache sampling settings: %v", err)
+	}
+}
+
+// nextUpdateInterval returns the update interval randomly advanced or delayed
+// by up to updateJitterRatio of it.
+func (su *settingsUpdater) nextUpdateInterval() time.Duration {
+	spread := time.Duration(float64(su.updateInterval) * su.updateJitterRatio)
+	return su.updateInterval - spread + jitter(2*spread)
+}
+
+// jitter returns a random duration in [0, d]
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.RandInt63n(int64(d) + 1))
+}
+
 func (su *settingsUpdater) ttlSettingsCheck(ready chan bool) {
 	defer func() { ready <- true }()
 
 	su.oboe.CheckSettingsTimeout()
 	if !su.oboe.HasDefaultSetting() {
-		log.Warning("sampling settings expired, APM library may not be functioning correctly")
+		settingsLogger.Limited("settings expired").Warning("sampling settings expired, APM library may not be functioning correctly")
 	}
 }
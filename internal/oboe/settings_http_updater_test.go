@@ -15,6 +15,7 @@
 package oboe
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -74,29 +75,17 @@ func TestSettingsUpdater_QueriesAndUpdatesSettings(t *testing.T) {
 	updater, err := NewSettingsUpdater(o, serviceName)
 	require.NoError(t, err)
 	require.NotNil(t, updater)
+	// fetch the settings right away
+	updater.(*settingsUpdater).startupJitter = 0
 
 	assert.False(t, o.HasDefaultSetting(), "oboe should not have default settings initially")
 
 	stop := updater.Start(t.Context())
 	defer stop()
 
-	// Wait for the HTTP server to be called and settings to be synchronized
-	timeout := time.After(2 * time.Second)
-	ticker := time.NewTicker(10 * time.Millisecond)
-	defer ticker.Stop()
-
-	synchronized := false
-	for !synchronized {
-		select {
-		case <-timeout:
-			t.Fatalf("timeout waiting for synchronization. requestCount=%d", requestCount.Load())
-		case <-ticker.C:
-			if requestCount.Load() > 0 {
-				synchronized = true
-			}
-		}
-	}
-
+	// The settings are stored after the request is served, so wait for them
+	// rather than for the request
+	require.Eventually(t, o.HasDefaultSetting, 2*time.Second, 10*time.Millisecond, "timeout waiting for synchronization")
 	assert.Equal(t, int32(1), requestCount.Load(), "HTTP server should have been called once")
 
 	storedSettings := o.GetSetting()
@@ -112,3 +101,173 @@ func TestSettingsUpdater_QueriesAndUpdatesSettings(t *testing.T) {
 	assert.Equal(t, float64(expectedSettings.Arguments.TriggerStrictBucketCapacity), storedSettings.triggerTraceStrictBucket.capacity, "trigger strict bucket capacity should match")
 	assert.Equal(t, float64(expectedSettings.Arguments.TriggerStrictBucketRate), storedSettings.triggerTraceStrictBucket.ratePerSec, "trigger strict bucket rate should match")
 }
+
+func newTestUpdater(t *testing.T, handler http.HandlerFunc, b backoff) (*settingsUpdater, *atomic.Int32) {
+	t.Helper()
+	var requestCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return &settingsUpdater{
+		oboe:            NewOboe(),
+		settingsService: newSettingsService(server.URL, "test-service", "", "token"),
+		backoff:         b,
+	}, &requestCount
+}
+
+func writeTestSettings(t *testing.T, w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(t, json.NewEncoder(w).Encode(httpSettings{
+		Flags:     "SAMPLE_START,SAMPLE_THROUGH_ALWAYS",
+		Value:     1000000,
+		Ttl:       120,
+		Arguments: &httpSettingArguments{BucketCapacity: 1, BucketRate: 1},
+	}))
+}
+
+func TestSettingsUpdater_RetriesWithBackoff(t *testing.T) {
+	var failures atomic.Int32
+	failures.Store(2)
+	su, requestCount := newTestUpdater(t, func(w http.ResponseWriter, r *http.Request) {
+		if failures.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		writeTestSettings(t, w)
+	}, backoff{initial: time.Millisecond, max: 2 * time.Millisecond, maxRetries: 5, logThreshold: 10})
+
+	ready := make(chan bool, 1)
+	require.True(t, su.getAndUpdateSettings(t.Context(), ready))
+	require.Equal(t, int32(3), requestCount.Load())
+	require.True(t, su.oboe.HasDefaultSetting())
+	require.Equal(t, 0, su.failures)
+	require.NoError(t, su.Status().LastError)
+	require.True(t, <-ready)
+}
+
+func TestSettingsUpdater_MaxRetries(t *testing.T) {
+	su, requestCount := newTestUpdater(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}, backoff{initial: time.Millisecond, max: time.Millisecond, maxRetries: 2, logThreshold: 10})
+
+	ready := make(chan bool, 1)
+	require.True(t, su.getAndUpdateSettings(t.Context(), ready))
+	require.Equal(t, int32(3), requestCount.Load())
+	require.False(t, su.oboe.HasDefaultSetting())
+	require.Equal(t, 3, su.failures)
+	require.ErrorContains(t, su.Status().LastError, "unexpected status code 502")
+
+	// The failures keep being counted across scheduled updates
+	<-ready
+	require.True(t, su.getAndUpdateSettings(t.Context(), ready))
+	require.Equal(t, 6, su.failures)
+}
+
+func TestSettingsUpdater_HonorsRetryAfter(t *testing.T) {
+	var first atomic.Bool
+	first.Store(true)
+	su, requestCount := newTestUpdater(t, func(w http.ResponseWriter, r *http.Request) {
+		if first.Swap(false) {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		writeTestSettings(t, w)
+	}, backoff{initial: time.Hour, max: time.Hour, maxRetries: 1, logThreshold: 10})
+
+	// The backoff delay is one hour, the test would time out if Retry-After
+	// was ignored.
+	ready := make(chan bool, 1)
+	require.True(t, su.getAndUpdateSettings(t.Context(), ready))
+	require.Equal(t, int32(2), requestCount.Load())
+	require.True(t, su.oboe.HasDefaultSetting())
+}
+
+func TestSettingsUpdater_CapsRetryAfter(t *testing.T) {
+	var first atomic.Bool
+	first.Store(true)
+	su, requestCount := newTestUpdater(t, func(w http.ResponseWriter, r *http.Request) {
+		if first.Swap(false) {
+			w.Header().Set("Retry-After", "86400")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writeTestSettings(t, w)
+	}, backoff{initial: time.Millisecond, max: time.Millisecond, maxRetries: 1, logThreshold: 10})
+
+	// The test would time out if the delay wasn't capped to the max backoff
+	ready := make(chan bool, 1)
+	require.True(t, su.getAndUpdateSettings(t.Context(), ready))
+	require.Equal(t, int32(2), requestCount.Load())
+	require.True(t, su.oboe.HasDefaultSetting())
+}
+
+func TestSettingsUpdater_StopsRetryingOnCancel(t *testing.T) {
+	su, requestCount := newTestUpdater(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, backoff{initial: time.Hour, max: time.Hour, maxRetries: 5, logThreshold: 10})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	ready := make(chan bool, 1)
+	require.True(t, su.getAndUpdateSettings(ctx, ready))
+	require.Equal(t, int32(1), requestCount.Load())
+}
+
+func TestSettingsUpdater_InvalidServiceKeyIsNotRetried(t *testing.T) {
+	su, requestCount := newTestUpdater(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}, backoff{initial: time.Millisecond, max: time.Millisecond, maxRetries: 5, logThreshold: 10})
+
+	ready := make(chan bool, 1)
+	require.False(t, su.getAndUpdateSettings(t.Context(), ready))
+	require.Equal(t, int32(1), requestCount.Load())
+}
+
+func TestSettingsUpdater_NotModifiedExtendsSettings(t *testing.T) {
+	var notModified atomic.Bool
+	su, requestCount := newTestUpdater(t, func(w http.ResponseWriter, r *http.Request) {
+		if notModified.Load() && r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		writeTestSettings(t, w)
+	}, backoff{initial: time.Millisecond, max: time.Millisecond, maxRetries: 1, logThreshold: 10})
+
+	ready := make(chan bool, 1)
+	require.True(t, su.getAndUpdateSettings(t.Context(), ready))
+	<-ready
+	before := su.oboe.GetSetting()
+
+	notModified.Store(true)
+	require.True(t, su.getAndUpdateSettings(t.Context(), ready))
+	<-ready
+	after := su.oboe.GetSetting()
+	require.Equal(t, int32(2), requestCount.Load())
+	require.True(t, after.timestamp.After(before.timestamp))
+	require.Same(t, before.bucket, after.bucket)
+	require.NoError(t, su.Status().LastError)
+
+	// Once the settings expired, they are fetched unconditionally
+	su.oboe.RemoveSetting()
+	require.True(t, su.getAndUpdateSettings(t.Context(), ready))
+	require.Equal(t, int32(3), requestCount.Load())
+	require.True(t, su.oboe.HasDefaultSetting())
+	require.NotSame(t, before.bucket, su.oboe.GetSetting().bucket)
+}
+
+func TestSettingsUpdater_NextUpdateInterval(t *testing.T) {
+	su := &settingsUpdater{updateInterval: time.Minute, updateJitterRatio: 0.1}
+	for range 100 {
+		d := su.nextUpdateInterval()
+		require.GreaterOrEqual(t, d, 54*time.Second)
+		require.LessOrEqual(t, d, 66*time.Second)
+	}
+	su.updateJitterRatio = 0
+	require.Equal(t, time.Minute, su.nextUpdateInterval())
+	require.Equal(t, time.Duration(0), jitter(0))
+	require.LessOrEqual(t, jitter(time.Second), time.Second)
+}
@@ -29,6 +29,8 @@ type tokenBucket struct {
 	available  float64
 	last       time.Time
 	lock       sync.Mutex
+	// the name of the transaction if it's a per-transaction bucket
+	transaction string
 }
 
 func (b *tokenBucket) setRateCap(rate, cap float64) {
@@ -56,6 +58,9 @@ func (b *tokenBucket) count(sampled, hasMetadata, rateLimit, isTriggerTrace bool
 	if rateLimit {
 		if ok := b.consume(1); !ok {
 			metrics.RatesAggregator().LimitedInc()
+			if b.transaction != "" {
+				metrics.TransactionRatesAggregator().LimitedInc(b.transaction)
+			}
 			return false
 		}
 	}
@@ -73,9 +78,13 @@ func (b *tokenBucket) count(sampled, hasMetadata, rateLimit, isTriggerTrace bool
 }
 
 func (b *tokenBucket) consume(size float64) bool {
+	return b.consumeAt(size, time.Now())
+}
+
+func (b *tokenBucket) consumeAt(size float64, now time.Time) bool {
 	b.lock.Lock()
 	defer b.lock.Unlock()
-	b.update(time.Now())
+	b.update(now)
 	if b.available >= size {
 		b.available -= size
 		return true
@@ -84,15 +93,32 @@ func (b *tokenBucket) consume(size float64) bool {
 }
 
 func (b *tokenBucket) update(now time.Time) {
-	if b.available < b.capacity { // room for more tokens?
-		delta := now.Sub(b.last) // calculate duration since last check
-		b.last = now             // update time of last check
-		if delta <= 0 {          // return if no delta or time went "backwards"
-			return
-		}
-		newTokens := b.ratePerSec * delta.Seconds()               // # tokens generated since last check
-		b.available = math.Min(b.capacity, b.available+newTokens) // add new tokens to bucket, but don't overfill
+	// The time of last check is updated even if the bucket is full, so that no
+	// tokens are credited for the time it stayed full.
+	delta := now.Sub(b.last) // calculate duration since last check
+	b.last = now             // update time of last check
+	if delta <= 0 {          // return if no delta or time went "backwards"
+		return
 	}
+	if b.available >= b.capacity { // no room for more tokens
+		return
+	}
+	newTokens := b.ratePerSec * delta.Seconds()               // # tokens generated since last check
+	b.available = math.Min(b.capacity, b.available+newTokens) // add new tokens to bucket, but don't overfill
+}
+
+// carryOver copies the available tokens, clamped to the capacity of this
+// bucket, and the time of the last check from the bucket it replaces, so that
+// replacing the bucket neither starves nor bursts the sampling.
+func (b *tokenBucket) carryOver(old *tokenBucket) {
+	old.lock.Lock()
+	available, last := old.available, old.last
+	old.lock.Unlock()
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.available = math.Min(available, b.capacity)
+	b.last = last
 }
 
 func floatToStr(f float64) string {
@@ -0,0 +1,143 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oboe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucketConsume(t *testing.T) {
+	now := time.Now()
+	b := &tokenBucket{last: now}
+	b.setRateCap(2, 4)
+
+	require.False(t, b.consumeAt(1, now))
+	// 2 tokens per second
+	require.True(t, b.consumeAt(1, now.Add(time.Second)))
+	require.True(t, b.consumeAt(1, now.Add(time.Second)))
+	require.False(t, b.consumeAt(1, now.Add(time.Second)))
+	// never more than the capacity
+	require.True(t, b.consumeAt(4, now.Add(time.Hour)))
+	require.False(t, b.consumeAt(1, now.Add(time.Hour)))
+}
+
+func TestTokenBucketNoCreditWhileFull(t *testing.T) {
+	now := time.Now()
+	b := &tokenBucket{last: now, available: 4}
+	b.setRateCap(1, 4)
+
+	// The bucket stayed full for a minute, consuming it doesn't refill it
+	// with the tokens of that minute.
+	now = now.Add(time.Minute)
+	for range 4 {
+		require.True(t, b.consumeAt(1, now))
+	}
+	require.False(t, b.consumeAt(1, now))
+	require.True(t, b.consumeAt(1, now.Add(time.Second)))
+	require.False(t, b.consumeAt(1, now.Add(time.Second)))
+}
+
+func TestTokenBucketCarryOver(t *testing.T) {
+	last := time.Now().Add(-time.Second)
+	old := &tokenBucket{ratePerSec: 1, capacity: 10, available: 7, last: last}
+
+	b := &tokenBucket{}
+	b.setRateCap(2, 20)
+	b.carryOver(old)
+	require.Equal(t, 7.0, b.available)
+	require.Equal(t, last, b.last)
+	require.Equal(t, 2.0, b.ratePerSec)
+	require.Equal(t, 20.0, b.capacity)
+
+	// clamped to the new capacity
+	b = &tokenBucket{}
+	b.setRateCap(1, 5)
+	b.carryOver(old)
+	require.Equal(t, 5.0, b.available)
+	require.Equal(t, last, b.last)
+}
+
+func TestUpdateSettingCarriesOverBuckets(t *testing.T) {
+	capacity := 3.0
+	config.Load(config.WithTransactionFilters([]config.TransactionFilter{
+		{Type: config.Transaction, Name: "/api/orders", TokenBucketCap: &capacity},
+	}))
+	t.Cleanup(func() { config.Load() })
+
+	last := time.Now().Add(-time.Second)
+	o := NewOboe()
+	o.UpdateSetting(GetDefaultSettingForTest())
+	s := o.GetSetting()
+	for i, b := range []*tokenBucket{
+		s.bucket, s.triggerTraceRelaxedBucket, s.triggerTraceStrictBucket, s.transactions["/api/orders"].bucket,
+	} {
+		b.available = float64(i + 1)
+		b.last = last
+	}
+
+	args := GetDefaultSettingForTest()
+	args.BucketCapacity = 0.5
+	o.UpdateSetting(args)
+	ns := o.GetSetting()
+	require.NotSame(t, s, ns)
+	require.Equal(t, 0.5, ns.bucket.available) // clamped to the new capacity
+	require.Equal(t, 2.0, ns.triggerTraceRelaxedBucket.available)
+	require.Equal(t, 3.0, ns.triggerTraceStrictBucket.available)
+	require.Equal(t, 3.0, ns.transactions["/api/orders"].bucket.available)
+	for _, b := range []*tokenBucket{
+		ns.bucket, ns.triggerTraceRelaxedBucket, ns.triggerTraceStrictBucket, ns.transactions["/api/orders"].bucket,
+	} {
+		require.Equal(t, last, b.last)
+	}
+}
+
+// TestSmoothThroughputAcrossRefreshes sends 5 requests every 100ms for a
+// minute while the settings are refreshed every second, and checks that the
+// bucket lets through its rate without ever starving or bursting.
+func TestSmoothThroughputAcrossRefreshes(t *testing.T) {
+	args := GetDefaultSettingForTest()
+	args.BucketCapacity = 10
+	args.BucketRate = 10
+	o := NewOboe()
+	o.UpdateSetting(args)
+
+	now := time.Now()
+	o.GetSetting().bucket.last = now
+	granted := 0
+	for step := 1; step <= 600; step++ {
+		now = now.Add(100 * time.Millisecond)
+		if step%10 == 0 {
+			o.UpdateSetting(args)
+		}
+		stepGranted := 0
+		for range 5 {
+			if o.GetSetting().bucket.consumeAt(1, now) {
+				stepGranted++
+			}
+		}
+		// 1 token per 100ms, allowing for floating point rounding
+		require.LessOrEqual(t, stepGranted, 2, "step %d", step)
+		if step > 1 {
+			require.GreaterOrEqual(t, stepGranted, 1, "step %d", step)
+		}
+		granted += stepGranted
+	}
+	// the rate for 60s, give or take a token
+	require.InDelta(t, 600, granted, 1)
+}
@@ -0,0 +1,81 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oboe
+
+import (
+	"github.com/solarwinds/apm-go/internal/config"
+)
+
+// transactionSetting holds the sampling settings of a single transaction,
+// merged from its local `transaction` filter and the service level settings.
+type transactionSetting struct {
+	value  int
+	flags  settingFlag
+	source SampleSource
+	// the transaction's own token bucket, or the service's one if the filter
+	// doesn't define any
+	bucket *tokenBucket
+}
+
+// mergeTransactionSettings builds the per-transaction settings from the local
+// `transaction` filters. It follows the same precedence as MergeLocalSetting
+// and must be called after it, once the service token bucket is set.
+func (s *settings) mergeTransactionSettings(filters []config.TransactionFilter) {
+	for _, filter := range filters {
+		if filter.Type != config.Transaction {
+			continue
+		}
+		ts := &transactionSetting{
+			value:  s.value,
+			flags:  s.flags,
+			source: s.source,
+			bucket: s.bucket,
+		}
+
+		if filter.SampleRate != nil {
+			rate := *filter.SampleRate
+			// Choose the lower sample rate if the remote setting is authoritative
+			if !s.hasOverrideFlag() || rate < ts.value {
+				ts.value = rate
+				ts.source = SampleSourceFile
+			}
+		}
+
+		if mode := NewTracingMode(filter.Tracing); !mode.isUnknown() {
+			ts.flags = mode.toFlags()
+			if s.hasOverrideFlag() {
+				ts.flags &= s.originalFlags
+			}
+			ts.source = SampleSourceFile
+		}
+
+		if filter.HasTokenBucket() {
+			capacity, rate := s.bucket.capacity, s.bucket.ratePerSec
+			if filter.TokenBucketCap != nil {
+				capacity = *filter.TokenBucketCap
+			}
+			if filter.TokenBucketRate != nil {
+				rate = *filter.TokenBucketRate
+			}
+			ts.bucket = &tokenBucket{transaction: filter.Name}
+			ts.bucket.setRateCap(rate, capacity)
+		}
+
+		if s.transactions == nil {
+			s.transactions = make(map[string]*transactionSetting)
+		}
+		s.transactions[filter.Name] = ts
+	}
+}
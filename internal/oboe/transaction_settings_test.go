@@ -0,0 +1,149 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oboe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/solarwinds/apm-go/internal/constants"
+	"github.com/solarwinds/apm-go/internal/metrics"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestMergeTransactionSettings(t *testing.T) {
+	rate, capacity, bucketRate := 1000, 2.0, 0.5
+	o := &oboe{}
+	o.UpdateSetting(GetDefaultSettingForTest())
+	s := o.GetSetting()
+	s.mergeTransactionSettings([]config.TransactionFilter{
+		{Type: config.URL, RegEx: `/healthz`, Tracing: config.DisabledTracingMode},
+		{Type: config.Transaction, Name: "/api/orders", SampleRate: &rate, TokenBucketCap: &capacity},
+		{Type: config.Transaction, Name: "/api/users", TokenBucketRate: &bucketRate},
+		{Type: config.Transaction, Name: "/internal", Tracing: config.DisabledTracingMode},
+	})
+	require.Len(t, s.transactions, 3)
+
+	orders := s.transactions["/api/orders"]
+	require.Equal(t, 1000, orders.value)
+	require.Equal(t, SampleSourceFile, orders.source)
+	require.Equal(t, s.flags, orders.flags)
+	require.NotSame(t, s.bucket, orders.bucket)
+	require.Equal(t, 2.0, orders.bucket.capacity)
+	require.Equal(t, s.bucket.ratePerSec, orders.bucket.ratePerSec)
+	require.Equal(t, "/api/orders", orders.bucket.transaction)
+
+	users := s.transactions["/api/users"]
+	require.Equal(t, s.value, users.value)
+	require.Equal(t, SampleSourceDefault, users.source)
+	require.Equal(t, s.bucket.capacity, users.bucket.capacity)
+	require.Equal(t, 0.5, users.bucket.ratePerSec)
+
+	internal := s.transactions["/internal"]
+	require.False(t, internal.flags.Enabled())
+	require.Same(t, s.bucket, internal.bucket)
+}
+
+func TestMergeTransactionSettingsOverride(t *testing.T) {
+	low, high := 1000, config.MaxSampleRate
+	args := GetDefaultSettingForTest()
+	args.Flags = "OVERRIDE,SAMPLE_START,SAMPLE_THROUGH_ALWAYS"
+	args.Value = 500000
+	o := &oboe{}
+	o.UpdateSetting(args)
+	s := o.GetSetting()
+	s.mergeTransactionSettings([]config.TransactionFilter{
+		{Type: config.Transaction, Name: "low", SampleRate: &low},
+		{Type: config.Transaction, Name: "high", SampleRate: &high},
+	})
+
+	// The lower sample rate wins with the OVERRIDE flag
+	require.Equal(t, 1000, s.transactions["low"].value)
+	require.Equal(t, SampleSourceFile, s.transactions["low"].source)
+	require.Equal(t, 500000, s.transactions["high"].value)
+	require.Equal(t, SampleSourceDefault, s.transactions["high"].source)
+}
+
+func TestSampleRequestPerTransactionBucket(t *testing.T) {
+	capacity, rate := 1.0, 0.0
+	config.Load(config.WithTransactionFilters([]config.TransactionFilter{
+		{Type: config.Transaction, Name: "/api/noisy", TokenBucketCap: &capacity, TokenBucketRate: &rate},
+	}))
+	t.Cleanup(func() { config.Load() })
+
+	o := NewOboe()
+	o.UpdateSetting(GetDefaultSettingForTest())
+	// fill the transaction bucket
+	o.GetSetting().transactions["/api/noisy"].bucket.available = 1
+	metrics.RatesAggregator().FlushRateCounts()
+	metrics.TransactionRatesAggregator().FlushLimited()
+
+	dec := o.SampleRequest(false, "", "/api/noisy", ModeTriggerTraceNotPresent, unsampledSwState)
+	require.True(t, dec.Trace())
+	require.Equal(t, 1.0, dec.BucketCapacity())
+	require.Equal(t, 0.0, dec.BucketRate())
+
+	// The transaction's bucket is exhausted ...
+	for range 3 {
+		dec = o.SampleRequest(false, "", "/api/noisy", ModeTriggerTraceNotPresent, unsampledSwState)
+		require.False(t, dec.Trace())
+	}
+	// ... but the other transactions still use the service bucket
+	dec = o.SampleRequest(false, "", "/api/other", ModeTriggerTraceNotPresent, unsampledSwState)
+	require.True(t, dec.Trace())
+	require.Equal(t, 1000000.0, dec.BucketCapacity())
+
+	require.Equal(t, map[string]int64{"/api/noisy": 3}, metrics.TransactionRatesAggregator().FlushLimited())
+	counts := o.FlushRateCounts()
+	require.Equal(t, int64(5), counts.Requested)
+	require.Equal(t, int64(3), counts.Limited)
+	require.Equal(t, int64(2), counts.Traced)
+}
+
+func TestRegisterOtelSampleRateMetricsPerTransaction(t *testing.T) {
+	o := NewOboe()
+	o.UpdateSetting(GetDefaultSettingForTest())
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	require.NoError(t, o.RegisterOtelSampleRateMetrics(mp))
+
+	metrics.RatesAggregator().FlushRateCounts()
+	metrics.TransactionRatesAggregator().FlushLimited()
+	metrics.RatesAggregator().LimitedInc()
+	metrics.TransactionRatesAggregator().LimitedInc("/api/noisy")
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	var points []metricdata.DataPoint[int64]
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name == "trace.service.tokenbucket_exhaustion_count" {
+			points = m.Data.(metricdata.Gauge[int64]).DataPoints
+		}
+	}
+	require.Len(t, points, 2)
+	for _, p := range points {
+		require.Equal(t, int64(1), p.Value)
+		if txn, ok := p.Attributes.Value(constants.SwTransactionNameAttribute); ok {
+			require.Equal(t, "/api/noisy", txn.AsString())
+		} else {
+			require.Equal(t, 0, p.Attributes.Len())
+		}
+	}
+}
@@ -17,27 +17,29 @@ package oboe
 import (
 	"fmt"
 	"github.com/solarwinds/apm-go/internal/config"
-	"github.com/solarwinds/apm-go/internal/log"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync/atomic"
 
 	"github.com/coocood/freecache"
 )
 
-var urls *urlFilters
+// urls holds the URL filters, which are replaced as a whole when the
+// transaction filters are reloaded
+var urls atomic.Pointer[urlFilters]
 
 func init() {
-	urls = newURLFilters()
-	urls.LoadConfig(config.GetTransactionFiltering())
+	ReloadURLsConfig(config.GetTransactionFiltering())
 }
 
-// ReloadURLsConfig reloads the configuration and build the transaction filtering
-// filters and cache.
-// This function is used for testing purpose only. It's not thread-safe.
+// ReloadURLsConfig builds the transaction filtering filters and cache from the
+// given filters. They replace the current ones atomically, so it may be called
+// while requests are being sampled.
 func ReloadURLsConfig(filters []config.TransactionFilter) {
-	urls.LoadConfig(filters)
-	urls.cache.Clear()
+	f := newURLFilters()
+	f.LoadConfig(filters)
+	urls.Store(f)
 }
 
 // urlCache is a cache to store the disabled url patterns
@@ -108,8 +110,12 @@ func newExtensionFilter(extensions []string, mode TracingMode) *extensionFilter
 	return &extensionFilter{Exts: exts, trace: mode}
 }
 
-// match checks if the url matches the filter
+// match checks if the url matches the filter. The query string and fragment
+// are ignored so that e.g. `/logo.png?v=2` still matches the `png` extension.
 func (f *extensionFilter) match(url string) bool {
+	if idx := strings.IndexAny(url, "?#"); idx != -1 {
+		url = url[:idx]
+	}
 	ext := strings.TrimLeft(filepath.Ext(url), ".")
 	_, ok := f.Exts[ext]
 	return ok
@@ -140,10 +146,14 @@ func (f *urlFilters) loadConfig(filters []config.TransactionFilter) {
 	f.filters = nil
 
 	for _, filter := range filters {
+		if filter.Type != config.URL {
+			continue
+		}
 		if filter.RegEx != "" {
 			re, err := newRegexFilter(filter.RegEx, NewTracingMode(filter.Tracing))
 			if err != nil {
-				log.Warningf("Ignore bad regex: %s, error=", filter.RegEx, err.Error())
+				logger.Warningf("Ignore bad regex: %s, error=%s", filter.RegEx, err.Error())
+				continue
 			}
 			f.filters = append(f.filters, re)
 		} else {
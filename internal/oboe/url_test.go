@@ -71,6 +71,9 @@ func TestUrlFilter(t *testing.T) {
 
 	assert.Equal(t, TraceDisabled, filter.GetTracingMode("http://user.com/eric/avatar.png"))
 	assert.Equal(t, int64(4), filter.cache.EntryCount())
+
+	assert.Equal(t, TraceDisabled, filter.GetTracingMode("/eric/avatar.jpg?size=large#top"))
+	assert.Equal(t, int64(5), filter.cache.EntryCount())
 }
 
 func TestReloadURLsConfig(t *testing.T) {
@@ -84,33 +87,33 @@ func TestReloadURLsConfig(t *testing.T) {
 	ReloadURLsConfig([]config.TransactionFilter{
 		{Type: "url", Extensions: []string{"gif"}, Tracing: config.DisabledTracingMode},
 	})
-	assert.Equal(t, TraceDisabled, urls.GetTracingMode("image.gif"))
-	assert.Equal(t, TraceUnknown, urls.GetTracingMode("image.png"))
-	assert.Equal(t, int64(2), urls.cache.EntryCount())
-	assert.Equal(t, int64(0), urls.cache.HitCount())
+	assert.Equal(t, TraceDisabled, urls.Load().GetTracingMode("image.gif"))
+	assert.Equal(t, TraceUnknown, urls.Load().GetTracingMode("image.png"))
+	assert.Equal(t, int64(2), urls.Load().cache.EntryCount())
+	assert.Equal(t, int64(0), urls.Load().cache.HitCount())
 
 	// Reload with a different config; cache must also be cleared
 	ReloadURLsConfig([]config.TransactionFilter{
 		{Type: "url", Extensions: []string{"png"}, Tracing: config.DisabledTracingMode},
 	})
-	assert.Equal(t, TraceUnknown, urls.GetTracingMode("image.gif"))
-	assert.Equal(t, TraceDisabled, urls.GetTracingMode("image.png"))
-	assert.Equal(t, int64(2), urls.cache.EntryCount())
-	assert.Equal(t, int64(0), urls.cache.HitCount())
+	assert.Equal(t, TraceUnknown, urls.Load().GetTracingMode("image.gif"))
+	assert.Equal(t, TraceDisabled, urls.Load().GetTracingMode("image.png"))
+	assert.Equal(t, int64(2), urls.Load().cache.EntryCount())
+	assert.Equal(t, int64(0), urls.Load().cache.HitCount())
 
 	// Reload with a different config; cache must also be cleared
 	ReloadURLsConfig([]config.TransactionFilter{
 		{Type: "url", Extensions: []string{"jpeg"}, Tracing: config.EnabledTracingMode},
 	})
-	assert.Equal(t, TraceEnabled, urls.GetTracingMode("image.jpeg"))
-	assert.Equal(t, TraceEnabled, urls.GetTracingMode("image.jpeg"))
-	assert.Equal(t, TraceUnknown, urls.GetTracingMode("image.gif"))
-	assert.Equal(t, TraceUnknown, urls.GetTracingMode("image.png"))
-	assert.Equal(t, int64(3), urls.cache.EntryCount())
-	assert.Equal(t, int64(1), urls.cache.HitCount())
+	assert.Equal(t, TraceEnabled, urls.Load().GetTracingMode("image.jpeg"))
+	assert.Equal(t, TraceEnabled, urls.Load().GetTracingMode("image.jpeg"))
+	assert.Equal(t, TraceUnknown, urls.Load().GetTracingMode("image.gif"))
+	assert.Equal(t, TraceUnknown, urls.Load().GetTracingMode("image.png"))
+	assert.Equal(t, int64(3), urls.Load().cache.EntryCount())
+	assert.Equal(t, int64(1), urls.Load().cache.HitCount())
 
 	// Reload to empty; cache must be cleared (restoration to original is via t.Cleanup)
 	ReloadURLsConfig(nil)
-	assert.Equal(t, int64(0), urls.cache.EntryCount())
-	assert.Equal(t, int64(0), urls.cache.HitCount())
+	assert.Equal(t, int64(0), urls.Load().cache.EntryCount())
+	assert.Equal(t, int64(0), urls.Load().cache.HitCount())
 }
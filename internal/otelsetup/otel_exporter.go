@@ -20,8 +20,32 @@ import (
 
 	"github.com/solarwinds/apm-go/internal/config"
 	"github.com/solarwinds/apm-go/internal/log"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc/status"
 )
 
+// logger prints the logs of the setup of the exporters
+var logger = log.WithComponent("exporter")
+
+// ErrorHandler returns the handler of the errors of the OpenTelemetry SDK, e.g.
+// the failures to export the spans and metrics. The repeated errors are rate
+// limited so that an unreachable collector doesn't flood the logs.
+func ErrorHandler() otel.ErrorHandler {
+	return otel.ErrorHandlerFunc(func(err error) {
+		logger.Limited(errorKey(err)).Warningf("export error: %v", err)
+	})
+}
+
+// errorKey returns the rate limiting key of err. The messages of the errors
+// vary, e.g. with the number of items dropped, so the key is the gRPC status
+// code if err has one.
+func errorKey(err error) string {
+	if s, ok := status.FromError(err); ok {
+		return "export failed: " + s.Code().String()
+	}
+	return "export failed"
+}
+
 func isExportingToSwo(exporterEndpoint string) bool {
 	return strings.Contains(exporterEndpoint, "solarwinds.com")
 }
@@ -47,12 +71,12 @@ func getAndSetupExporterEndpoint(specificExporterEnvVariable string) string {
 	} else {
 		swApmOtelCollector := config.GetOtelCollector()
 		if err := os.Setenv(specificExporterEnvVariable, swApmOtelCollector); err != nil {
-			log.Warningf("could not override unset %s %s", specificExporterEnvVariable, err)
+			logger.Warningf("could not override unset %s %s", specificExporterEnvVariable, err)
 		} else {
 			exporterEndpoint = swApmOtelCollector
 		}
 	}
-	log.Infof("Otel span exporter endpoint: %s", exporterEndpoint)
+	logger.Infof("Otel span exporter endpoint: %s", exporterEndpoint)
 
 	return exporterEndpoint
 }
@@ -0,0 +1,53 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"context"
+
+	"github.com/solarwinds/apm-go/internal/metrics"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewOutboundMetricsSpanProcessor returns a span processor that records the
+// metrics of the client and producer spans.
+func NewOutboundMetricsSpanProcessor(registry metrics.MetricRegistry) sdktrace.SpanProcessor {
+	return &outboundMetricsSpanProcessor{
+		registry: registry,
+	}
+}
+
+var _ sdktrace.SpanProcessor = &outboundMetricsSpanProcessor{}
+
+type outboundMetricsSpanProcessor struct {
+	registry metrics.MetricRegistry
+}
+
+func (s *outboundMetricsSpanProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+func (s *outboundMetricsSpanProcessor) OnEnd(span sdktrace.ReadOnlySpan) {
+	if kind := span.SpanKind(); kind == trace.SpanKindClient || kind == trace.SpanKindProducer {
+		s.registry.RecordSpan(span)
+	}
+}
+
+func (s *outboundMetricsSpanProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+func (s *outboundMetricsSpanProcessor) ForceFlush(context.Context) error {
+	return nil
+}
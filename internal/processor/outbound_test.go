@@ -0,0 +1,49 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type countingRegistry struct {
+	spans []sdktrace.ReadOnlySpan
+}
+
+func (r *countingRegistry) RecordSpan(span sdktrace.ReadOnlySpan) {
+	r.spans = append(r.spans, span)
+}
+
+func TestOutboundMetricsSpanProcessor(t *testing.T) {
+	registry := &countingRegistry{}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(NewOutboundMetricsSpanProcessor(registry)))
+	tracer := tp.Tracer("foo")
+	for _, kind := range []trace.SpanKind{
+		trace.SpanKindServer, trace.SpanKindClient, trace.SpanKindProducer,
+		trace.SpanKindConsumer, trace.SpanKindInternal,
+	} {
+		_, s := tracer.Start(context.Background(), kind.String(), trace.WithSpanKind(kind))
+		s.End()
+	}
+
+	require.Len(t, registry.spans, 2)
+	require.Equal(t, trace.SpanKindClient, registry.spans[0].SpanKind())
+	require.Equal(t, trace.SpanKindProducer, registry.spans[1].SpanKind())
+}
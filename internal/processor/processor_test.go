@@ -18,10 +18,15 @@ import (
 	"context"
 	"testing"
 
+	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/solarwinds/apm-go/internal/constants"
 	"github.com/solarwinds/apm-go/internal/entryspans"
 	"github.com/solarwinds/apm-go/internal/metrics"
+	"github.com/solarwinds/apm-go/internal/swotel/semconv"
+	"github.com/solarwinds/apm-go/internal/txn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -158,3 +163,22 @@ func TestSpanIsRemovedFromStateManagerAfterSpanEnds(t *testing.T) {
 	_, ok = entryspans.Current(entrySpan.SpanContext().TraceID())
 	require.False(t, ok)
 }
+
+func TestInboundMetricsSpanProcessorOnStartPrependsDomain(t *testing.T) {
+	config.Load(func(c *config.Config) { c.PrependDomain = true })
+	t.Cleanup(func() { config.Load() })
+
+	mock := &recordMock{}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(NewInboundMetricsSpanProcessor(mock)))
+	_, s := tp.Tracer("foo").Start(context.Background(), "GET", trace.WithAttributes(
+		semconv.ServerAddressKey.String("api.example.com"),
+		semconv.HTTPRouteKey.String("/v1/users"),
+	))
+	s.End()
+
+	require.True(t, mock.called)
+	require.Contains(t, mock.span.Attributes(),
+		attribute.String(constants.SwTransactionNameAttribute, "api.example.com/v1/users"))
+	// The same name is used by the response time histogram
+	require.Equal(t, "api.example.com/v1/users", txn.GetTransactionName(mock.span))
+}
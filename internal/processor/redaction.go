@@ -0,0 +1,243 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"context"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/solarwinds/apm-go/internal/swotel/semconv"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// redacted replaces the redacted values
+const redacted = "[REDACTED]"
+
+// NewRedactionSpanProcessor returns a span processor that redacts the span
+// attributes, the event attributes (including the messages recorded by
+// `RecordError`) and the status description before handing the spans to next,
+// which is usually the exporting processor.
+//
+// The attributes whose key matches one of the configured keys are replaced
+// entirely, while the parts of the string values matching one of the
+// configured regular expressions are replaced. If reportQueryString is false,
+// the query string is also removed from `url.full` and `http.target`.
+func NewRedactionSpanProcessor(next sdktrace.SpanProcessor, cfg config.RedactionConfig, reportQueryString bool) sdktrace.SpanProcessor {
+	r := &redactionSpanProcessor{
+		next:   next,
+		values: cfg.CompiledValues(),
+	}
+	for _, k := range cfg.Keys {
+		if strings.ContainsAny(k, `*?[\`) {
+			r.patterns = append(r.patterns, k)
+		} else {
+			if r.keys == nil {
+				r.keys = make(map[attribute.Key]bool)
+			}
+			r.keys[attribute.Key(k)] = true
+		}
+	}
+	if !reportQueryString {
+		if r.keys == nil {
+			r.keys = make(map[attribute.Key]bool)
+		}
+		r.keys[semconv.URLQueryKey] = true
+		r.stripQuery = true
+	}
+	return r
+}
+
+var _ sdktrace.SpanProcessor = &redactionSpanProcessor{}
+
+type redactionSpanProcessor struct {
+	next sdktrace.SpanProcessor
+	// the keys matched exactly
+	keys map[attribute.Key]bool
+	// the keys matched as glob patterns
+	patterns []string
+	values   []*regexp.Regexp
+	// strips the query string from the URLs
+	stripQuery bool
+}
+
+func (r *redactionSpanProcessor) OnStart(ctx context.Context, span sdktrace.ReadWriteSpan) {
+	if attrs, ok := r.redactAttributes(span.Attributes()); ok {
+		span.SetAttributes(attrs...)
+	}
+	r.next.OnStart(ctx, span)
+}
+
+func (r *redactionSpanProcessor) OnEnd(span sdktrace.ReadOnlySpan) {
+	rs := &redactedSpan{ReadOnlySpan: span}
+	changed := false
+	if attrs, ok := r.redactAttributes(span.Attributes()); ok {
+		rs.attrs = attrs
+		changed = true
+	}
+	if events, ok := r.redactEvents(span.Events()); ok {
+		rs.events = events
+		changed = true
+	}
+	if status := span.Status(); status.Description != "" {
+		if desc := r.redactString(status.Description); desc != status.Description {
+			status.Description = desc
+			rs.status = &status
+			changed = true
+		}
+	}
+	if changed {
+		span = rs
+	}
+	r.next.OnEnd(span)
+}
+
+func (r *redactionSpanProcessor) Shutdown(ctx context.Context) error {
+	return r.next.Shutdown(ctx)
+}
+
+func (r *redactionSpanProcessor) ForceFlush(ctx context.Context) error {
+	return r.next.ForceFlush(ctx)
+}
+
+// redactAttributes returns a redacted copy of attrs and true if any of them
+// changed.
+func (r *redactionSpanProcessor) redactAttributes(attrs []attribute.KeyValue) ([]attribute.KeyValue, bool) {
+	var res []attribute.KeyValue
+	for i, kv := range attrs {
+		if redactedKV, ok := r.redact(kv); ok {
+			if res == nil {
+				res = make([]attribute.KeyValue, len(attrs))
+				copy(res, attrs)
+			}
+			res[i] = redactedKV
+		}
+	}
+	return res, res != nil
+}
+
+// redactEvents returns a copy of events with their attributes redacted and
+// true if any of them changed.
+func (r *redactionSpanProcessor) redactEvents(events []sdktrace.Event) ([]sdktrace.Event, bool) {
+	var res []sdktrace.Event
+	for i, e := range events {
+		if attrs, ok := r.redactAttributes(e.Attributes); ok {
+			if res == nil {
+				res = make([]sdktrace.Event, len(events))
+				copy(res, events)
+			}
+			res[i].Attributes = attrs
+		}
+	}
+	return res, res != nil
+}
+
+// redact returns the redacted attribute and true if it changed
+func (r *redactionSpanProcessor) redact(kv attribute.KeyValue) (attribute.KeyValue, bool) {
+	if r.matchKey(kv.Key) {
+		return kv.Key.String(redacted), true
+	}
+	switch kv.Value.Type() {
+	case attribute.STRING:
+		s := kv.Value.AsString()
+		redactedStr := r.redactString(s)
+		if r.stripQuery && (kv.Key == semconv.URLFullKey || kv.Key == semconv.HTTPTargetKey) {
+			redactedStr = stripQueryString(redactedStr)
+		}
+		if redactedStr != s {
+			return kv.Key.String(redactedStr), true
+		}
+	case attribute.STRINGSLICE:
+		if len(r.values) == 0 {
+			break
+		}
+		changed := false
+		ss := kv.Value.AsStringSlice()
+		for i, s := range ss {
+			if redactedStr := r.redactString(s); redactedStr != s {
+				ss[i] = redactedStr
+				changed = true
+			}
+		}
+		if changed {
+			return kv.Key.StringSlice(ss), true
+		}
+	}
+	return kv, false
+}
+
+func (r *redactionSpanProcessor) matchKey(key attribute.Key) bool {
+	if r.keys[key] {
+		return true
+	}
+	for _, p := range r.patterns {
+		if ok, _ := path.Match(p, string(key)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *redactionSpanProcessor) redactString(s string) string {
+	for _, re := range r.values {
+		s = re.ReplaceAllLiteralString(s, redacted)
+	}
+	return s
+}
+
+// stripQueryString removes the query string from the URL, keeping the fragment
+func stripQueryString(url string) string {
+	start := strings.IndexByte(url, '?')
+	if start < 0 {
+		return url
+	}
+	if end := strings.IndexByte(url[start:], '#'); end >= 0 {
+		return url[:start] + url[start+end:]
+	}
+	return url[:start]
+}
+
+// redactedSpan overrides the attributes, events and status of a span that
+// has ended. The nil fields are not overridden.
+type redactedSpan struct {
+	sdktrace.ReadOnlySpan
+	attrs  []attribute.KeyValue
+	events []sdktrace.Event
+	status *sdktrace.Status
+}
+
+func (s *redactedSpan) Attributes() []attribute.KeyValue {
+	if s.attrs != nil {
+		return s.attrs
+	}
+	return s.ReadOnlySpan.Attributes()
+}
+
+func (s *redactedSpan) Events() []sdktrace.Event {
+	if s.events != nil {
+		return s.events
+	}
+	return s.ReadOnlySpan.Events()
+}
+
+func (s *redactedSpan) Status() sdktrace.Status {
+	if s.status != nil {
+		return *s.status
+	}
+	return s.ReadOnlySpan.Status()
+}
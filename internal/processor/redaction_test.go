@@ -0,0 +1,120 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/solarwinds/apm-go/internal/swotel/semconv"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const emailPattern = `[\w.+-]+@[\w-]+\.[\w.]+`
+
+func newRedactionTracer(cfg config.RedactionConfig, reportQueryString bool) (trace.Tracer, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewRedactionSpanProcessor(recorder, cfg, reportQueryString)),
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+	)
+	return tp.Tracer("foo"), recorder
+}
+
+func TestRedactionSpanProcessorAttributes(t *testing.T) {
+	tracer, recorder := newRedactionTracer(config.RedactionConfig{
+		Keys:   []string{"password", "http.request.header.*"},
+		Values: []string{emailPattern},
+	}, true)
+	_, s := tracer.Start(context.Background(), "login", trace.WithAttributes(
+		attribute.String("password", "hunter2"),
+		attribute.StringSlice("http.request.header.authorization", []string{"Bearer abc"}),
+		attribute.String("user.comment", "contact me at bob@example.com"),
+		attribute.String("user.password", "not matched"),
+		attribute.Int("count", 3),
+	))
+	started := recorder.Started()
+	require.Len(t, started, 1)
+	require.Equal(t, "[REDACTED]", spanAttribute(started[0], "password"))
+
+	s.SetAttributes(attribute.StringSlice("emails", []string{"a@b.io", "none"}))
+	s.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 1)
+	require.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("password", "[REDACTED]"),
+		attribute.String("http.request.header.authorization", "[REDACTED]"),
+		attribute.String("user.comment", "contact me at [REDACTED]"),
+		attribute.String("user.password", "not matched"),
+		attribute.Int("count", 3),
+		attribute.StringSlice("emails", []string{"[REDACTED]", "none"}),
+	}, ended[0].Attributes())
+}
+
+func TestRedactionSpanProcessorEventsAndStatus(t *testing.T) {
+	tracer, recorder := newRedactionTracer(config.RedactionConfig{
+		Keys:   []string{"token"},
+		Values: []string{emailPattern},
+	}, true)
+	_, s := tracer.Start(context.Background(), "signup")
+	err := errors.New("user bob@example.com already exists")
+	s.RecordError(err)
+	s.AddEvent("retry", trace.WithAttributes(attribute.String("token", "secret")))
+	s.SetStatus(codes.Error, err.Error())
+	s.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 1)
+	events := ended[0].Events()
+	require.Len(t, events, 2)
+	require.Equal(t, semconv.ExceptionEventName, events[0].Name)
+	require.Contains(t, events[0].Attributes, semconv.ExceptionMessageKey.String("user [REDACTED] already exists"))
+	require.Equal(t, []attribute.KeyValue{attribute.String("token", "[REDACTED]")}, events[1].Attributes)
+	require.Equal(t, "user [REDACTED] already exists", ended[0].Status().Description)
+}
+
+func TestRedactionSpanProcessorQueryString(t *testing.T) {
+	tracer, recorder := newRedactionTracer(config.RedactionConfig{}, false)
+	_, s := tracer.Start(context.Background(), "GET", trace.WithAttributes(
+		semconv.URLFullKey.String("https://example.com/search?q=secret#results"),
+		semconv.HTTPTargetKey.String("/search?q=secret"),
+		semconv.URLQueryKey.String("q=secret"),
+		semconv.URLPathKey.String("/search"),
+	))
+	s.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 1)
+	require.Equal(t, "https://example.com/search#results", spanAttribute(ended[0], semconv.URLFullKey))
+	require.Equal(t, "/search", spanAttribute(ended[0], semconv.HTTPTargetKey))
+	require.Equal(t, "[REDACTED]", spanAttribute(ended[0], semconv.URLQueryKey))
+	require.Equal(t, "/search", spanAttribute(ended[0], semconv.URLPathKey))
+
+	// The query string is kept if it's reported
+	tracer, recorder = newRedactionTracer(config.RedactionConfig{Keys: []string{"other"}}, true)
+	_, s = tracer.Start(context.Background(), "GET", trace.WithAttributes(
+		semconv.HTTPTargetKey.String("/search?q=secret"),
+	))
+	s.End()
+	require.Equal(t, "/search?q=secret", spanAttribute(recorder.Ended()[0], semconv.HTTPTargetKey))
+}
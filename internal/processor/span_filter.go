@@ -0,0 +1,240 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/solarwinds/apm-go/internal/entryspans"
+	"github.com/solarwinds/apm-go/internal/log"
+	"github.com/solarwinds/apm-go/internal/swotel/semconv"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	spanFilterMeterName     = "sw.apm.span.filter"
+	droppedSpansCounterName = "trace.service.spans.dropped"
+)
+
+// NewSpanFilterProcessor returns a span processor that drops the spans
+// matching one of the filters instead of handing them to next, which is
+// usually the exporting processor. The dropped spans are counted by
+// instrumentation scope with the meter provider p.
+//
+// To keep the trace structure consistent, the entry spans, the spans with an
+// error status and the spans whose children have started while the entry span
+// was running are never dropped.
+func NewSpanFilterProcessor(next sdktrace.SpanProcessor, filters []config.SpanFilter, p metric.MeterProvider) sdktrace.SpanProcessor {
+	dropped, err := p.Meter(spanFilterMeterName).Int64Counter(
+		droppedSpansCounterName,
+		metric.WithUnit("{span}"),
+		metric.WithDescription("The number of spans dropped by the span filters"),
+	)
+	if err != nil {
+		log.Warningf("Failed to create the dropped spans counter: %s", err)
+	}
+	compiled := make([]spanFilter, 0, len(filters))
+	for _, f := range filters {
+		compiled = append(compiled, newSpanFilter(f))
+	}
+	return &spanFilterProcessor{
+		next:    next,
+		filters: compiled,
+		dropped: dropped,
+	}
+}
+
+var _ sdktrace.SpanProcessor = &spanFilterProcessor{}
+
+type spanFilterProcessor struct {
+	next    sdktrace.SpanProcessor
+	filters []spanFilter
+	dropped metric.Int64Counter
+
+	// traces holds the *filterTrace of the sampled traces whose entry spans
+	// haven't ended, by trace.TraceID
+	traces sync.Map
+}
+
+// filterTrace holds the state of a trace, which is cleared once its entry
+// spans have ended
+type filterTrace struct {
+	mut sync.Mutex
+	// the number of entry spans which haven't ended
+	entries int
+	// ended is set once the trace is removed from spanFilterProcessor.traces
+	ended bool
+	// parents holds the spans whose children have started
+	parents map[trace.SpanID]struct{}
+}
+
+func (s *spanFilterProcessor) OnStart(ctx context.Context, span sdktrace.ReadWriteSpan) {
+	// The spans which are not sampled are never dropped
+	if span.SpanContext().IsSampled() {
+		s.started(span)
+	}
+	s.next.OnStart(ctx, span)
+}
+
+func (s *spanFilterProcessor) started(span sdktrace.ReadOnlySpan) {
+	tid := span.SpanContext().TraceID()
+	if entryspans.IsEntrySpan(span) {
+		for {
+			v, _ := s.traces.LoadOrStore(tid, &filterTrace{parents: make(map[trace.SpanID]struct{})})
+			t := v.(*filterTrace)
+			t.mut.Lock()
+			if !t.ended {
+				t.entries++
+				t.mut.Unlock()
+				return
+			}
+			// Removed meanwhile, retry with a new one
+			t.mut.Unlock()
+		}
+	}
+	// The spans which start after the entry spans ended are not tracked, so
+	// that the state doesn't outlive the trace
+	v, ok := s.traces.Load(tid)
+	if !ok {
+		return
+	}
+	t := v.(*filterTrace)
+	t.mut.Lock()
+	t.parents[span.Parent().SpanID()] = struct{}{}
+	t.mut.Unlock()
+}
+
+// ended returns if the children of span have started, and clears the state
+// of its trace once its last entry span has ended
+func (s *spanFilterProcessor) ended(span sdktrace.ReadOnlySpan) bool {
+	tid := span.SpanContext().TraceID()
+	v, ok := s.traces.Load(tid)
+	if !ok {
+		return false
+	}
+	t := v.(*filterTrace)
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	sid := span.SpanContext().SpanID()
+	_, isParent := t.parents[sid]
+	delete(t.parents, sid)
+	if entryspans.IsEntrySpan(span) {
+		t.entries--
+		if t.entries <= 0 {
+			t.ended = true
+			s.traces.Delete(tid)
+		}
+	}
+	return isParent
+}
+
+func (s *spanFilterProcessor) OnEnd(span sdktrace.ReadOnlySpan) {
+	isParent := span.SpanContext().IsSampled() && s.ended(span)
+	if !isParent && s.drop(span) {
+		if s.dropped != nil {
+			s.dropped.Add(context.Background(), 1, metric.WithAttributes(
+				semconv.OTelScopeNameKey.String(span.InstrumentationScope().Name),
+			))
+		}
+		return
+	}
+	s.next.OnEnd(span)
+}
+
+func (s *spanFilterProcessor) Shutdown(ctx context.Context) error {
+	return s.next.Shutdown(ctx)
+}
+
+func (s *spanFilterProcessor) ForceFlush(ctx context.Context) error {
+	return s.next.ForceFlush(ctx)
+}
+
+// drop returns if the span matches one of the filters. The spans which are not
+// sampled are not exported anyway, they're left to the next processor.
+func (s *spanFilterProcessor) drop(span sdktrace.ReadOnlySpan) bool {
+	if !span.SpanContext().IsSampled() || entryspans.IsEntrySpan(span) || span.Status().Code == codes.Error {
+		return false
+	}
+	for _, f := range s.filters {
+		if f.matches(span) {
+			return true
+		}
+	}
+	return false
+}
+
+// spanFilter is the compiled form of config.SpanFilter
+type spanFilter struct {
+	scope       *regexp.Regexp
+	name        *regexp.Regexp
+	kinds       map[trace.SpanKind]bool
+	minDuration time.Duration
+}
+
+func newSpanFilter(f config.SpanFilter) spanFilter {
+	filter := spanFilter{scope: f.ScopePattern()}
+	if f.Name != "" {
+		// The config is validated already
+		filter.name = regexp.MustCompile(f.Name)
+	}
+	if len(f.Kinds) > 0 {
+		filter.kinds = make(map[trace.SpanKind]bool, len(f.Kinds))
+		for _, k := range f.Kinds {
+			filter.kinds[spanKindFromString(k)] = true
+		}
+	}
+	if f.MinDuration != "" {
+		filter.minDuration, _ = time.ParseDuration(f.MinDuration)
+	}
+	return filter
+}
+
+func (f spanFilter) matches(span sdktrace.ReadOnlySpan) bool {
+	if f.scope != nil && !f.scope.MatchString(span.InstrumentationScope().Name) {
+		return false
+	}
+	if f.name != nil && !f.name.MatchString(span.Name()) {
+		return false
+	}
+	if f.kinds != nil && !f.kinds[span.SpanKind()] {
+		return false
+	}
+	if f.minDuration > 0 && span.EndTime().Sub(span.StartTime()) >= f.minDuration {
+		return false
+	}
+	return true
+}
+
+func spanKindFromString(kind string) trace.SpanKind {
+	switch kind {
+	case "server":
+		return trace.SpanKindServer
+	case "client":
+		return trace.SpanKindClient
+	case "producer":
+		return trace.SpanKindProducer
+	case "consumer":
+		return trace.SpanKindConsumer
+	default:
+		return trace.SpanKindInternal
+	}
+}
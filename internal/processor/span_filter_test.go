@@ -0,0 +1,159 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/solarwinds/apm-go/internal/swotel/semconv"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newSpanFilterTracerProvider(filters []config.SpanFilter) (*sdktrace.TracerProvider, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	recorder := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewSpanFilterProcessor(recorder, filters, mp)),
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+	)
+	return tp, recorder, reader
+}
+
+func endedSpanNames(recorder *tracetest.SpanRecorder) []string {
+	var names []string
+	for _, s := range recorder.Ended() {
+		names = append(names, s.Name())
+	}
+	return names
+}
+
+func TestSpanFilterProcessor(t *testing.T) {
+	tp, recorder, reader := newSpanFilterTracerProvider([]config.SpanFilter{
+		{Scope: "github.com/redis/*"},
+		{Name: `^row\.`, Kinds: []string{"internal"}},
+		{Kinds: []string{"client"}, MinDuration: "1h"},
+	})
+	app := tp.Tracer("app")
+	redis := tp.Tracer("github.com/redis/go-redis/extra/redisotel")
+
+	ctx, entry := app.Start(context.Background(), "GET /users")
+	_, s := redis.Start(ctx, "pipeline")
+	s.End()
+	_, s = app.Start(ctx, "row.scan")
+	s.End()
+	_, s = app.Start(ctx, "row.scan", trace.WithSpanKind(trace.SpanKindProducer))
+	s.End()
+	_, s = app.Start(ctx, "rows")
+	s.End()
+	// Only the fast client spans are dropped
+	_, s = app.Start(ctx, "http.get", trace.WithSpanKind(trace.SpanKindClient))
+	s.End()
+	start := time.Now()
+	_, s = app.Start(ctx, "http.slow", trace.WithSpanKind(trace.SpanKindClient), trace.WithTimestamp(start.Add(-2*time.Hour)))
+	s.End(trace.WithTimestamp(start))
+	// The errors are kept
+	_, s = redis.Start(ctx, "get")
+	s.SetStatus(codes.Error, "timeout")
+	s.End()
+	// The spans whose children started are kept
+	childCtx, parent := redis.Start(ctx, "parent")
+	_, child := app.Start(childCtx, "child")
+	child.End()
+	parent.End()
+	entry.End()
+
+	require.Equal(t, []string{"row.scan", "rows", "http.slow", "get", "child", "parent", "GET /users"},
+		endedSpanNames(recorder))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+	require.Equal(t, droppedSpansCounterName, rm.ScopeMetrics[0].Metrics[0].Name)
+	sum, ok := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	dropped := make(map[string]int64)
+	for _, dp := range sum.DataPoints {
+		scope, _ := dp.Attributes.Value(semconv.OTelScopeNameKey)
+		dropped[scope.AsString()] = dp.Value
+	}
+	require.Equal(t, map[string]int64{
+		"github.com/redis/go-redis/extra/redisotel": 1,
+		"app": 2,
+	}, dropped)
+}
+
+func TestSpanFilterProcessorKeepsEntrySpans(t *testing.T) {
+	tp, recorder, _ := newSpanFilterTracerProvider([]config.SpanFilter{{Scope: "*"}})
+	tracer := tp.Tracer("app")
+
+	_, root := tracer.Start(context.Background(), "root")
+	root.End()
+	remote := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}))
+	ctx, entry := tracer.Start(remote, "entry")
+	_, child := tracer.Start(ctx, "child", trace.WithAttributes(attribute.String("foo", "bar")))
+	child.End()
+	entry.End()
+
+	require.Equal(t, []string{"root", "entry"}, endedSpanNames(recorder))
+}
+
+func TestSpanFilterProcessorClearsTraces(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	proc := NewSpanFilterProcessor(recorder, []config.SpanFilter{{Name: "^late$"}},
+		sdkmetric.NewMeterProvider()).(*spanFilterProcessor)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(proc))
+	tracer := tp.Tracer("app")
+	traces := func() int {
+		n := 0
+		proc.traces.Range(func(any, any) bool {
+			n++
+			return true
+		})
+		return n
+	}
+
+	ctx, entry := tracer.Start(context.Background(), "entry")
+	ctx, parent := tracer.Start(ctx, "parent")
+	// The parent never ends
+	_ = parent
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+	require.Equal(t, 1, traces())
+	entry.End()
+	require.Zero(t, traces())
+
+	// The spans starting after the entry span ended aren't tracked
+	_, late := tracer.Start(ctx, "late")
+	require.Zero(t, traces())
+	late.End()
+	require.Equal(t, []string{"child", "entry"}, endedSpanNames(recorder))
+}
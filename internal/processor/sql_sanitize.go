@@ -0,0 +1,122 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"context"
+
+	"github.com/solarwinds/apm-go/internal/sqlsanitizer"
+	"github.com/solarwinds/apm-go/internal/swotel/semconv"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// NewSQLSanitizeSpanProcessor returns a span processor that sanitizes the
+// database statements of spans according to the SQLSanitize level before
+// handing them to next, which is usually the exporting processor.
+//
+// The statements set when the span starts are rewritten in place, so that the
+// processors registered after this one do not see them either. The statements
+// set later are sanitized when the span ends.
+func NewSQLSanitizeSpanProcessor(next sdktrace.SpanProcessor, level int) sdktrace.SpanProcessor {
+	return &sqlSanitizeSpanProcessor{
+		next:  next,
+		level: level,
+	}
+}
+
+var _ sdktrace.SpanProcessor = &sqlSanitizeSpanProcessor{}
+
+type sqlSanitizeSpanProcessor struct {
+	next  sdktrace.SpanProcessor
+	level int
+}
+
+func (s *sqlSanitizeSpanProcessor) OnStart(ctx context.Context, span sdktrace.ReadWriteSpan) {
+	if sanitized, ok := s.sanitize(span.Attributes()); ok {
+		span.SetAttributes(sanitized...)
+	}
+	s.next.OnStart(ctx, span)
+}
+
+func (s *sqlSanitizeSpanProcessor) OnEnd(span sdktrace.ReadOnlySpan) {
+	attrs := span.Attributes()
+	if sanitized, ok := s.sanitize(attrs); ok {
+		span = &sanitizedSpan{ReadOnlySpan: span, attrs: replaceAttributes(attrs, sanitized)}
+	}
+	s.next.OnEnd(span)
+}
+
+func (s *sqlSanitizeSpanProcessor) Shutdown(ctx context.Context) error {
+	return s.next.Shutdown(ctx)
+}
+
+func (s *sqlSanitizeSpanProcessor) ForceFlush(ctx context.Context) error {
+	return s.next.ForceFlush(ctx)
+}
+
+// sanitize returns the sanitized statement attributes and true if any of them
+// changed.
+func (s *sqlSanitizeSpanProcessor) sanitize(attrs []attribute.KeyValue) ([]attribute.KeyValue, bool) {
+	var system string
+	var statements []attribute.KeyValue
+	for _, kv := range attrs {
+		switch kv.Key {
+		case semconv.DBSystemNameKey, semconv.DBSystemKey:
+			if system == "" {
+				system = kv.Value.AsString()
+			}
+		case semconv.DBQueryTextKey, semconv.DBStatementKey:
+			statements = append(statements, kv)
+		}
+	}
+	if len(statements) == 0 {
+		return nil, false
+	}
+
+	dialect := sqlsanitizer.DialectFromDBSystem(system)
+	var sanitized []attribute.KeyValue
+	for _, kv := range statements {
+		query := kv.Value.AsString()
+		if q := sqlsanitizer.Sanitize(query, s.level, dialect); q != query {
+			sanitized = append(sanitized, kv.Key.String(q))
+		}
+	}
+	return sanitized, len(sanitized) > 0
+}
+
+// replaceAttributes returns a copy of attrs with the values of the replacements
+func replaceAttributes(attrs []attribute.KeyValue, replacements []attribute.KeyValue) []attribute.KeyValue {
+	result := make([]attribute.KeyValue, len(attrs))
+	copy(result, attrs)
+	for i, kv := range result {
+		for _, r := range replacements {
+			if kv.Key == r.Key {
+				result[i] = r
+			}
+		}
+	}
+	return result
+}
+
+// sanitizedSpan overrides the attributes of a span that has ended
+type sanitizedSpan struct {
+	sdktrace.ReadOnlySpan
+	attrs []attribute.KeyValue
+}
+
+func (s *sanitizedSpan) Attributes() []attribute.KeyValue {
+	return s.attrs
+}
@@ -0,0 +1,99 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/solarwinds/apm-go/internal/sqlsanitizer"
+	"github.com/solarwinds/apm-go/internal/swotel/semconv"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newSQLSanitizeTracer(level int) (trace.Tracer, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewSQLSanitizeSpanProcessor(recorder, level)),
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+	)
+	return tp.Tracer("foo"), recorder
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) string {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value.AsString()
+		}
+	}
+	return ""
+}
+
+func TestSQLSanitizeSpanProcessorOnStart(t *testing.T) {
+	tracer, recorder := newSQLSanitizeTracer(sqlsanitizer.EnabledAuto)
+	_, s := tracer.Start(context.Background(), "query", trace.WithAttributes(
+		semconv.DBSystemKey.String("mysql"),
+		semconv.DBQueryTextKey.String(`SELECT * FROM users WHERE name = "bob" AND id = 5`),
+		semconv.DBStatementKey.String(`SELECT * FROM users WHERE name = "bob" AND id = 5`),
+	))
+
+	// The statements are already sanitized for the other processors
+	started := recorder.Started()
+	require.Len(t, started, 1)
+	require.Equal(t, "SELECT * FROM users WHERE name = ? AND id = ?", spanAttribute(started[0], semconv.DBQueryTextKey))
+	s.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 1)
+	require.Equal(t, "SELECT * FROM users WHERE name = ? AND id = ?", spanAttribute(ended[0], semconv.DBQueryTextKey))
+	require.Equal(t, "SELECT * FROM users WHERE name = ? AND id = ?", spanAttribute(ended[0], semconv.DBStatementKey))
+	require.Equal(t, "mysql", spanAttribute(ended[0], semconv.DBSystemKey))
+}
+
+func TestSQLSanitizeSpanProcessorOnEnd(t *testing.T) {
+	tracer, recorder := newSQLSanitizeTracer(sqlsanitizer.EnabledAuto)
+	_, s := tracer.Start(context.Background(), "query")
+	s.SetAttributes(
+		semconv.DBSystemNameKey.String("postgresql"),
+		semconv.DBQueryTextKey.String(`SELECT "Name" FROM users WHERE id = 5`),
+		attribute.String("other", "'kept'"),
+	)
+	s.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 1)
+	require.Equal(t, `SELECT "Name" FROM users WHERE id = ?`, spanAttribute(ended[0], semconv.DBQueryTextKey))
+	require.Equal(t, "'kept'", spanAttribute(ended[0], "other"))
+}
+
+func TestSQLSanitizeSpanProcessorLevels(t *testing.T) {
+	query := `SELECT "Name" FROM users WHERE id = 5`
+	for level, expected := range map[int]string{
+		sqlsanitizer.Disabled:                query,
+		sqlsanitizer.EnabledDropDoubleQuoted: "SELECT ? FROM users WHERE id = ?",
+		sqlsanitizer.EnabledKeepDoubleQuoted: `SELECT "Name" FROM users WHERE id = ?`,
+	} {
+		tracer, recorder := newSQLSanitizeTracer(level)
+		_, s := tracer.Start(context.Background(), "query", trace.WithAttributes(
+			semconv.DBQueryTextKey.String(query),
+		))
+		s.End()
+		require.Equal(t, expected, spanAttribute(recorder.Ended()[0], semconv.DBQueryTextKey), "level %d", level)
+	}
+}
@@ -0,0 +1,228 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/solarwinds/apm-go/internal/entryspans"
+	"github.com/solarwinds/apm-go/internal/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// TailSamplingReasonKey is set on the promoted entry spans, either to
+	// "error" or "latency"
+	TailSamplingReasonKey = attribute.Key("sw.tail_sampling.reason")
+
+	// bufferedTraceTTL is the time after which a buffered trace whose entry
+	// spans never ended is evicted
+	bufferedTraceTTL = 5 * time.Minute
+
+	// evictionInterval is the interval to evict the expired buffered traces
+	evictionInterval = time.Minute
+)
+
+// NewTailSamplingProcessor returns a span processor that buffers the spans of
+// the traces which are recorded but not sampled, instead of handing them to
+// next, which is usually the exporting processor. Once the last local entry
+// span of a trace ends, the trace is exported as sampled if one of its entry
+// spans ended with an error or took longer than the latency threshold, and is
+// discarded otherwise. The sampled spans are handed to next right away.
+//
+// The spans ending after the last entry span of their trace are discarded.
+func NewTailSamplingProcessor(next sdktrace.SpanProcessor, cfg config.TailSamplingConfig) sdktrace.SpanProcessor {
+	s := &tailSamplingProcessor{
+		next:             next,
+		latencyThreshold: time.Duration(cfg.LatencyThreshold) * time.Millisecond,
+		maxTraces:        cfg.MaxTraces,
+		maxSpansPerTrace: cfg.MaxSpansPerTrace,
+		traces:           make(map[trace.TraceID]*bufferedTrace),
+		done:             make(chan struct{}),
+	}
+	go s.evictLoop()
+	return s
+}
+
+var _ sdktrace.SpanProcessor = &tailSamplingProcessor{}
+
+type tailSamplingProcessor struct {
+	next             sdktrace.SpanProcessor
+	latencyThreshold time.Duration
+	maxTraces        int
+	maxSpansPerTrace int
+
+	mut    sync.Mutex
+	traces map[trace.TraceID]*bufferedTrace
+
+	// done stops the eviction of the expired traces
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+type bufferedTrace struct {
+	created time.Time
+	spans   []sdktrace.ReadOnlySpan
+	// The reason to promote the trace, empty if it's discarded
+	reason string
+}
+
+func (s *tailSamplingProcessor) OnStart(ctx context.Context, span sdktrace.ReadWriteSpan) {
+	s.next.OnStart(ctx, span)
+}
+
+func (s *tailSamplingProcessor) OnEnd(span sdktrace.ReadOnlySpan) {
+	if span.SpanContext().IsSampled() {
+		s.next.OnEnd(span)
+		return
+	}
+	if promoted := s.buffer(span); promoted != nil {
+		log.Debugf("Promote trace %s (%s) with %d spans", span.SpanContext().TraceID(),
+			promoted.reason, len(promoted.spans))
+		for _, sp := range promoted.spans {
+			s.next.OnEnd(sp)
+		}
+	}
+}
+
+// buffer holds the span and returns the trace to export once it completes
+func (s *tailSamplingProcessor) buffer(span sdktrace.ReadOnlySpan) *bufferedTrace {
+	tid := span.SpanContext().TraceID()
+	isEntry := entryspans.IsEntrySpan(span)
+	now := time.Now()
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	t, ok := s.traces[tid]
+	if !ok {
+		// The trace completed already, or its entry span was not recorded
+		if !isEntry && entryspans.TraceCount(tid) == 0 {
+			return nil
+		}
+		if len(s.traces) >= s.maxTraces && !s.evictExpired(now) {
+			log.Debugf("Tail sampling buffer is full, discarding trace %s", tid)
+			return nil
+		}
+		t = &bufferedTrace{created: now}
+		s.traces[tid] = t
+	}
+	// The entry spans are always kept so that the promoted trace has a root
+	if isEntry || len(t.spans) < s.maxSpansPerTrace {
+		t.spans = append(t.spans, promotedSpan{ReadOnlySpan: span})
+	}
+	if !isEntry {
+		return nil
+	}
+	if reason := s.promotionReason(span); reason != "" {
+		t.reason = reason
+		last := len(t.spans) - 1
+		t.spans[last] = promotedSpan{ReadOnlySpan: span, reason: reason}
+	}
+	// The entry span is still tracked until the processors registered after
+	// the exporting one are done with it
+	if entryspans.TraceCount(tid) > 1 {
+		return nil
+	}
+	delete(s.traces, tid)
+	if t.reason == "" {
+		return nil
+	}
+	return t
+}
+
+func (s *tailSamplingProcessor) promotionReason(span sdktrace.ReadOnlySpan) string {
+	if span.Status().Code == codes.Error {
+		return "error"
+	}
+	if s.latencyThreshold > 0 && span.EndTime().Sub(span.StartTime()) > s.latencyThreshold {
+		return "latency"
+	}
+	return ""
+}
+
+// evictLoop evicts the expired traces every evictionInterval until the
+// processor is shut down
+func (s *tailSamplingProcessor) evictLoop() {
+	ticker := time.NewTicker(evictionInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case now := <-ticker.C:
+			s.mut.Lock()
+			s.evictExpired(now)
+			s.mut.Unlock()
+		}
+	}
+}
+
+// evictExpired discards the buffered traces older than bufferedTraceTTL and
+// returns if any was. It must be called with the lock held.
+func (s *tailSamplingProcessor) evictExpired(now time.Time) bool {
+	evicted := false
+	for tid, t := range s.traces {
+		if now.Sub(t.created) > bufferedTraceTTL {
+			delete(s.traces, tid)
+			evicted = true
+		}
+	}
+	return evicted
+}
+
+func (s *tailSamplingProcessor) Shutdown(ctx context.Context) error {
+	s.stopOnce.Do(func() { close(s.done) })
+	s.mut.Lock()
+	clear(s.traces)
+	s.mut.Unlock()
+	return s.next.Shutdown(ctx)
+}
+
+func (s *tailSamplingProcessor) ForceFlush(ctx context.Context) error {
+	return s.next.ForceFlush(ctx)
+}
+
+// promotedSpan marks a buffered span as sampled so that it's exported
+type promotedSpan struct {
+	sdktrace.ReadOnlySpan
+	reason string
+}
+
+func (p promotedSpan) SpanContext() trace.SpanContext {
+	sc := p.ReadOnlySpan.SpanContext()
+	return sc.WithTraceFlags(sc.TraceFlags().WithSampled(true))
+}
+
+func (p promotedSpan) Parent() trace.SpanContext {
+	parent := p.ReadOnlySpan.Parent()
+	if !parent.IsValid() || parent.IsRemote() {
+		return parent
+	}
+	return parent.WithTraceFlags(parent.TraceFlags().WithSampled(true))
+}
+
+func (p promotedSpan) Attributes() []attribute.KeyValue {
+	attrs := p.ReadOnlySpan.Attributes()
+	if p.reason == "" {
+		return attrs
+	}
+	return append(attrs[:len(attrs):len(attrs)], TailSamplingReasonKey.String(p.reason))
+}
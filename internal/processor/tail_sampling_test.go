@@ -0,0 +1,154 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTailSamplingTracer(cfg config.TailSamplingConfig, sampler sdktrace.Sampler) (trace.Tracer, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewTailSamplingProcessor(recorder, cfg)),
+		sdktrace.WithSpanProcessor(NewInboundMetricsSpanProcessor(&countingRegistry{})),
+		sdktrace.WithSampler(sampler),
+	)
+	return tp.Tracer("foo"), recorder
+}
+
+func TestTailSamplingPromotesErrors(t *testing.T) {
+	tracer, recorder := newTailSamplingTracer(config.TailSamplingConfig{MaxTraces: 10, MaxSpansPerTrace: 10}, recordOnlySampler{})
+
+	ctx, entry := tracer.Start(context.Background(), "entry")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+	require.Empty(t, recorder.Ended())
+	entry.SetStatus(codes.Error, "oops")
+	entry.End()
+
+	ended := recorder.Ended()
+	require.Equal(t, []string{"child", "entry"}, endedSpanNames(recorder))
+	for _, s := range ended {
+		require.True(t, s.SpanContext().IsSampled())
+	}
+	require.True(t, ended[0].Parent().IsSampled())
+	require.Contains(t, ended[1].Attributes(), TailSamplingReasonKey.String("error"))
+	require.NotContains(t, ended[0].Attributes(), TailSamplingReasonKey.String("error"))
+}
+
+func TestTailSamplingPromotesSlowTraces(t *testing.T) {
+	tracer, recorder := newTailSamplingTracer(config.TailSamplingConfig{
+		LatencyThreshold: 100, MaxTraces: 10, MaxSpansPerTrace: 10,
+	}, recordOnlySampler{})
+
+	// Fast traces are discarded
+	_, entry := tracer.Start(context.Background(), "fast")
+	entry.End()
+	require.Empty(t, recorder.Ended())
+
+	now := time.Now()
+	_, entry = tracer.Start(context.Background(), "slow", trace.WithTimestamp(now.Add(-time.Second)))
+	entry.End(trace.WithTimestamp(now))
+	require.Equal(t, []string{"slow"}, endedSpanNames(recorder))
+	require.Contains(t, recorder.Ended()[0].Attributes(), attribute.String("sw.tail_sampling.reason", "latency"))
+}
+
+func TestTailSamplingPassesSampledSpans(t *testing.T) {
+	tracer, recorder := newTailSamplingTracer(config.TailSamplingConfig{MaxTraces: 10, MaxSpansPerTrace: 10}, sdktrace.AlwaysSample())
+
+	ctx, entry := tracer.Start(context.Background(), "entry")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+	require.Equal(t, []string{"child"}, endedSpanNames(recorder))
+	entry.End()
+	require.Equal(t, []string{"child", "entry"}, endedSpanNames(recorder))
+}
+
+func TestTailSamplingWaitsForTheLastEntrySpan(t *testing.T) {
+	tracer, recorder := newTailSamplingTracer(config.TailSamplingConfig{MaxTraces: 10, MaxSpansPerTrace: 10}, recordOnlySampler{})
+
+	ctx, outer := tracer.Start(context.Background(), "outer")
+	_, client := tracer.Start(ctx, "client")
+	// The request comes back to the same process
+	remote := trace.ContextWithRemoteSpanContext(context.Background(),
+		client.SpanContext().WithRemote(true))
+	_, inner := tracer.Start(remote, "inner")
+	inner.SetStatus(codes.Error, "oops")
+	inner.End()
+	require.Empty(t, recorder.Ended())
+	client.End()
+	outer.End()
+
+	require.Equal(t, []string{"inner", "client", "outer"}, endedSpanNames(recorder))
+}
+
+func TestTailSamplingLimits(t *testing.T) {
+	tracer, recorder := newTailSamplingTracer(config.TailSamplingConfig{MaxTraces: 1, MaxSpansPerTrace: 1}, recordOnlySampler{})
+
+	ctx, entry := tracer.Start(context.Background(), "entry")
+	for _, name := range []string{"kept", "dropped"} {
+		_, child := tracer.Start(ctx, name)
+		child.End()
+	}
+	// The buffer is full, the other traces are discarded
+	otherCtx, other := tracer.Start(context.Background(), "other")
+	_, otherChild := tracer.Start(otherCtx, "other.child")
+	otherChild.End()
+	other.SetStatus(codes.Error, "oops")
+	other.End()
+	require.Empty(t, recorder.Ended())
+
+	entry.SetStatus(codes.Error, "oops")
+	entry.End()
+	require.Equal(t, []string{"kept", "entry"}, endedSpanNames(recorder))
+}
+
+func TestTailSamplingDiscardsLateSpans(t *testing.T) {
+	tracer, recorder := newTailSamplingTracer(config.TailSamplingConfig{MaxTraces: 1, MaxSpansPerTrace: 10}, recordOnlySampler{})
+
+	ctx, entry := tracer.Start(context.Background(), "entry")
+	_, async := tracer.Start(ctx, "async")
+	entry.End()
+	// The child ending after its entry span doesn't hold the buffer
+	async.End()
+
+	_, other := tracer.Start(context.Background(), "other")
+	other.SetStatus(codes.Error, "oops")
+	other.End()
+	require.Equal(t, []string{"other"}, endedSpanNames(recorder))
+}
+
+func TestTailSamplingEvictsExpiredTraces(t *testing.T) {
+	s := NewTailSamplingProcessor(tracetest.NewSpanRecorder(),
+		config.TailSamplingConfig{MaxTraces: 10, MaxSpansPerTrace: 10}).(*tailSamplingProcessor)
+	defer func() { require.NoError(t, s.Shutdown(context.Background())) }()
+	now := time.Now()
+	s.traces[trace.TraceID{1}] = &bufferedTrace{created: now.Add(-bufferedTraceTTL - time.Second)}
+	s.traces[trace.TraceID{2}] = &bufferedTrace{created: now}
+
+	require.True(t, s.evictExpired(now))
+	require.Len(t, s.traces, 1)
+	require.Contains(t, s.traces, trace.TraceID{2})
+}
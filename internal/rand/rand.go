@@ -49,3 +49,9 @@ func RandIntn(n int) int {
 	defer entropy.Unlock()
 	return entropy.rng.Intn(n)
 }
+
+func RandInt63n(n int64) int64 {
+	entropy.Lock()
+	defer entropy.Unlock()
+	return entropy.rng.Int63n(n)
+}
@@ -28,8 +28,10 @@ import (
 )
 
 type MetricsPublisher struct {
-	metricsRegistry metrics.MetricRegistry
-	meterProvider   *metric.MeterProvider
+	metricsRegistry         metrics.MetricRegistry
+	outboundMetricsRegistry metrics.MetricRegistry
+	customMetrics           *metrics.CustomMetrics
+	meterProvider           *metric.MeterProvider
 }
 
 func NewMetricsPublisher() *MetricsPublisher {
@@ -55,6 +57,7 @@ func newMeterProvider(ctx context.Context, resource *sdkresource.Resource, runti
 	return metric.NewMeterProvider(
 		metric.WithReader(otelMetricReader),
 		metric.WithResource(resource),
+		metric.WithView(metrics.ResponseTimeViews()...),
 	), nil
 }
 
@@ -80,6 +83,11 @@ func (c *MetricsPublisher) ConfigureAndStart(ctx context.Context, o oboe.Oboe, r
 	if err != nil {
 		return err
 	}
+	c.outboundMetricsRegistry, err = metrics.NewOtelOutboundRegistry(meterProvider)
+	if err != nil {
+		return err
+	}
+	c.customMetrics = metrics.NewCustomMetrics(meterProvider)
 	c.meterProvider = meterProvider
 
 	return nil
@@ -89,6 +97,22 @@ func (c *MetricsPublisher) GetMetricsRegistry() metrics.MetricRegistry {
 	return c.metricsRegistry
 }
 
+// GetOutboundMetricsRegistry returns the registry recording the metrics of
+// the client and producer spans
+func (c *MetricsPublisher) GetOutboundMetricsRegistry() metrics.MetricRegistry {
+	return c.outboundMetricsRegistry
+}
+
+// GetMeterProvider returns the meter provider exporting the metrics
+func (c *MetricsPublisher) GetMeterProvider() *metric.MeterProvider {
+	return c.meterProvider
+}
+
+// GetCustomMetrics returns the metrics reported by the user
+func (c *MetricsPublisher) GetCustomMetrics() *metrics.CustomMetrics {
+	return c.customMetrics
+}
+
 func (c *MetricsPublisher) Shutdown() error {
 	var err error
 	if c.meterProvider != nil {
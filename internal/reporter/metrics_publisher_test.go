@@ -32,6 +32,8 @@ func TestMetricsPublisherGetMetricsRegistryBeforeStart(t *testing.T) {
 	p := NewMetricsPublisher()
 
 	require.Nil(t, p.GetMetricsRegistry())
+	require.Nil(t, p.GetOutboundMetricsRegistry())
+	require.Nil(t, p.GetCustomMetrics())
 }
 
 func TestMetricsPublisherShutdownWhenNotConfigured(t *testing.T) {
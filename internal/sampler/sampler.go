@@ -16,9 +16,13 @@ package sampler
 
 import (
 	"fmt"
+	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/solarwinds/apm-go/internal/entryspans"
 	"github.com/solarwinds/apm-go/internal/log"
 	"github.com/solarwinds/apm-go/internal/oboe"
 	"github.com/solarwinds/apm-go/internal/swotel"
+	"github.com/solarwinds/apm-go/internal/swotel/semconv"
+	"github.com/solarwinds/apm-go/internal/txn"
 	"github.com/solarwinds/apm-go/internal/w3cfmt"
 	"github.com/solarwinds/apm-go/internal/xtrace"
 	"go.opentelemetry.io/otel/attribute"
@@ -26,8 +30,14 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+// logger prints the logs of the sampler
+var logger = log.WithComponent("sampler")
+
 type sampler struct {
 	oboe oboe.Oboe
+	// recordUnsampled records the children of the spans which are recorded
+	// but not sampled, so that their traces can be promoted by tail sampling
+	recordUnsampled bool
 }
 
 func NewSampler(o oboe.Oboe) (sdktrace.Sampler, error) {
@@ -35,7 +45,8 @@ func NewSampler(o oboe.Oboe) (sdktrace.Sampler, error) {
 		return nil, fmt.Errorf("oboe must not be nil")
 	}
 	return sampler{
-		oboe: o,
+		oboe:            o,
+		recordUnsampled: config.GetTailSampling().Enabled,
 	}, nil
 }
 
@@ -67,14 +78,14 @@ func hydrateTraceState(psc trace.SpanContext, xto xtrace.Options, ttResp string)
 		case xtrace.InvalidSignature:
 			full = fmt.Sprintf("auth=%s", xto.SigAuthMsg())
 		default:
-			log.Debugf("unknown signature state %s, not adding xtrace opts response header", xto.SignatureState())
+			logger.Limited("unknown signature state").Debugf("unknown signature state %s, not adding xtrace opts response header", xto.SignatureState())
 		}
 
 		if full != "" {
 			var err error
 			ts, err = swotel.SetInternalState(ts, swotel.XTraceOptResp, full)
 			if err != nil {
-				log.Debugf("could not set xtrace opts response header: %s", err)
+				logger.Limited("set xtrace opts response failed").Debugf("could not set xtrace opts response header: %s", err)
 			}
 		}
 	}
@@ -89,17 +100,25 @@ func (s sampler) ShouldSample(params sdktrace.SamplingParameters) sdktrace.Sampl
 	if psc.IsValid() && !psc.IsRemote() {
 		if psc.IsSampled() {
 			result = alwaysSampler.ShouldSample(params)
+		} else if s.recordUnsampled && trace.SpanFromContext(params.ParentContext).IsRecording() ||
+			isOutbound(params.Kind) && hasEntrySpan(psc.TraceID()) {
+			// The outbound spans of the traces which are not sampled are
+			// recorded for the outbound metrics, as the entry spans are
+			result = sdktrace.SamplingResult{
+				Decision:   sdktrace.RecordOnly,
+				Tracestate: psc.TraceState(),
+			}
 		} else {
 			result = neverSampler.ShouldSample(params)
 		}
 	} else {
-		// TODO url
-		url := ""
+		url := getURL(params.Attributes)
+		txnName := txn.DeriveTransactionName(params.Name, params.Attributes)
 		xto := xtrace.GetXTraceOptions(params.ParentContext, s.oboe)
 		ttMode := getTtMode(xto)
 		// If parent context is not valid, swState will also not be valid
 		swState := w3cfmt.GetSwTraceState(psc)
-		traceDecision := s.oboe.SampleRequest(swState.IsValid(), url, ttMode, swState)
+		traceDecision := s.oboe.SampleRequest(swState.IsValid(), url, txnName, ttMode, swState)
 		var decision sdktrace.SamplingDecision
 		if !traceDecision.Enabled() {
 			decision = sdktrace.Drop
@@ -128,10 +147,11 @@ func (s sampler) ShouldSample(params sdktrace.SamplingParameters) sdktrace.Sampl
 				attrs = append(attrs, attribute.String("sw.w3c.tracestate", capture.String()))
 			}
 
-			attrs = append(attrs, attribute.String("BucketCapacity", traceDecision.BucketCapacityStr()))
-			attrs = append(attrs, attribute.String("BucketRate", traceDecision.BucketRateStr()))
-			attrs = append(attrs, attribute.Int("SampleRate", traceDecision.SampleRate()))
-			attrs = append(attrs, attribute.Int("SampleSource", int(traceDecision.SampleSource())))
+			attrs = append(attrs, decisionAttributes(traceDecision)...)
+		} else if decision == sdktrace.RecordOnly && s.recordUnsampled {
+			// The traces promoted by tail sampling are exported with the
+			// attributes of the sampled ones
+			attrs = decisionAttributes(traceDecision)
 		}
 		result = sdktrace.SamplingResult{
 			Decision:   decision,
@@ -143,6 +163,55 @@ func (s sampler) ShouldSample(params sdktrace.SamplingParameters) sdktrace.Sampl
 
 }
 
+// decisionAttributes returns the attributes of the sampled entry spans
+// describing the sampling decision
+func decisionAttributes(d oboe.SampleDecision) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("BucketCapacity", d.BucketCapacityStr()),
+		attribute.String("BucketRate", d.BucketRateStr()),
+		attribute.Int("SampleRate", d.SampleRate()),
+		attribute.Int("SampleSource", int(d.SampleSource())),
+	}
+}
+
+// isOutbound returns whether the spans of the kind are recorded in the
+// outbound metrics
+func isOutbound(kind trace.SpanKind) bool {
+	return kind == trace.SpanKindClient || kind == trace.SpanKindProducer
+}
+
+// hasEntrySpan returns whether the entry span of the trace is recorded
+func hasEntrySpan(tid trace.TraceID) bool {
+	_, ok := entryspans.Current(tid)
+	return ok
+}
+
+// getURL derives the request URL used for transaction filtering from the span
+// start attributes. `url.full` is used when present, otherwise the URL is built
+// from `server.address` and `url.path` (or the deprecated `http.target`).
+func getURL(attrs []attribute.KeyValue) string {
+	var full, host, path, target string
+	for _, attr := range attrs {
+		switch attr.Key {
+		case semconv.URLFullKey:
+			full = attr.Value.AsString()
+		case semconv.ServerAddressKey:
+			host = attr.Value.AsString()
+		case semconv.URLPathKey:
+			path = attr.Value.AsString()
+		case semconv.HTTPTargetKey:
+			target = attr.Value.AsString()
+		}
+	}
+	if full != "" {
+		return full
+	}
+	if path == "" {
+		path = target
+	}
+	return host + path
+}
+
 func getTtMode(xto xtrace.Options) oboe.TriggerTraceMode {
 	if xto.TriggerTrace() {
 		switch xto.SignatureState() {
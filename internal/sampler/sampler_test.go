@@ -20,6 +20,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/solarwinds/apm-go/internal/entryspans"
 	"github.com/solarwinds/apm-go/internal/oboe"
 	"github.com/solarwinds/apm-go/internal/oboetestutils"
 	"github.com/solarwinds/apm-go/internal/swotel"
@@ -254,6 +256,167 @@ func TestLocalTraces(t *testing.T) {
 	scen.test(t)
 }
 
+type recordOnlySampler struct{}
+
+func (recordOnlySampler) ShouldSample(sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	return sdktrace.SamplingResult{Decision: sdktrace.RecordOnly}
+}
+
+func (recordOnlySampler) Description() string {
+	return "record only"
+}
+
+func TestRecordUnsampledChildren(t *testing.T) {
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(recordOnlySampler{}))
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	defer parent.End()
+	require.True(t, parent.IsRecording())
+	require.False(t, parent.SpanContext().IsSampled())
+	params := sdktrace.SamplingParameters{
+		ParentContext: ctx,
+		TraceID:       parent.SpanContext().TraceID(),
+		Name:          "child",
+	}
+
+	s := sampler{oboe: oboe.NewOboe(), recordUnsampled: true}
+	require.Equal(t, sdktrace.RecordOnly, s.ShouldSample(params).Decision)
+
+	s.recordUnsampled = false
+	require.Equal(t, sdktrace.Drop, s.ShouldSample(params).Decision)
+
+	// The children of the spans which are not recorded are dropped
+	s.recordUnsampled = true
+	params.ParentContext = trace.ContextWithSpanContext(context.Background(), parent.SpanContext())
+	require.Equal(t, sdktrace.Drop, s.ShouldSample(params).Decision)
+}
+
+func TestRecordUnsampledOutboundSpans(t *testing.T) {
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(recordOnlySampler{}))
+	_, entry := tp.Tracer("test").Start(context.Background(), "entry")
+	defer entry.End()
+	require.NoError(t, entryspans.Push(entry.(sdktrace.ReadWriteSpan)))
+	// The parent, e.g. an internal span, is not recorded
+	params := sdktrace.SamplingParameters{
+		ParentContext: trace.ContextWithSpanContext(context.Background(), entry.SpanContext()),
+		TraceID:       entry.SpanContext().TraceID(),
+		Name:          "client",
+		Kind:          trace.SpanKindClient,
+	}
+
+	s := sampler{oboe: oboe.NewOboe()}
+	require.Equal(t, sdktrace.RecordOnly, s.ShouldSample(params).Decision)
+	params.Kind = trace.SpanKindProducer
+	require.Equal(t, sdktrace.RecordOnly, s.ShouldSample(params).Decision)
+	params.Kind = trace.SpanKindInternal
+	require.Equal(t, sdktrace.Drop, s.ShouldSample(params).Decision)
+
+	// The traces whose entry span is not recorded are dropped
+	require.NoError(t, entryspans.Delete(entry.(sdktrace.ReadOnlySpan)))
+	params.Kind = trace.SpanKindClient
+	require.Equal(t, sdktrace.Drop, s.ShouldSample(params).Decision)
+}
+
+func TestRecordUnsampledEntrySpanAttributes(t *testing.T) {
+	o := oboe.NewOboe()
+	settings := oboetestutils.GetDefaultSettingForTest()
+	settings.Value = 0
+	o.UpdateSetting(settings)
+	params := sdktrace.SamplingParameters{
+		ParentContext: context.Background(),
+		TraceID:       traceId,
+		Name:          "entry",
+	}
+
+	// The entry spans recorded for tail sampling carry the sampling decision
+	s := sampler{oboe: o, recordUnsampled: true}
+	result := s.ShouldSample(params)
+	require.Equal(t, sdktrace.RecordOnly, result.Decision)
+	attrs := attribute.NewSet(result.Attributes...)
+	requireAttrEqual(t, attrs, "BucketCapacity", attribute.StringValue("1000000"))
+	requireAttrEqual(t, attrs, "BucketRate", attribute.StringValue("1000000"))
+	requireAttrEqual(t, attrs, "SampleRate", attribute.IntValue(0))
+	requireAttrEqual(t, attrs, "SampleSource", attribute.IntValue(int(oboe.SampleSourceDefault)))
+
+	s.recordUnsampled = false
+	result = s.ShouldSample(params)
+	require.Equal(t, sdktrace.RecordOnly, result.Decision)
+	require.Empty(t, result.Attributes)
+}
+
+func TestGetURL(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		attrs    []attribute.KeyValue
+		expected string
+	}{
+		{"none", nil, ""},
+		{"full", []attribute.KeyValue{
+			attribute.String("url.full", "https://example.com/foo?bar=baz"),
+			attribute.String("url.path", "/foo"),
+		}, "https://example.com/foo?bar=baz"},
+		{"host and path", []attribute.KeyValue{
+			attribute.String("server.address", "example.com"),
+			attribute.String("url.path", "/foo"),
+			attribute.String("http.target", "/foo?bar=baz"),
+		}, "example.com/foo"},
+		{"host and target", []attribute.KeyValue{
+			attribute.String("server.address", "example.com"),
+			attribute.String("http.target", "/foo?bar=baz"),
+		}, "example.com/foo?bar=baz"},
+		{"path only", []attribute.KeyValue{
+			attribute.String("url.path", "/foo"),
+		}, "/foo"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, getURL(tc.attrs))
+		})
+	}
+}
+
+func TestTransactionFiltering(t *testing.T) {
+	orig := config.GetTransactionFiltering()
+	t.Cleanup(func() { oboe.ReloadURLsConfig(orig) })
+	oboe.ReloadURLsConfig([]config.TransactionFilter{
+		{Type: config.URL, RegEx: `/healthz$`, Tracing: config.DisabledTracingMode},
+		{Type: config.URL, Extensions: []string{"png"}, Tracing: config.DisabledTracingMode},
+	})
+
+	o := oboe.NewOboe()
+	o.UpdateSetting(oboetestutils.GetDefaultSettingForTest())
+	smplr, err := NewSampler(o)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name     string
+		attrs    []attribute.KeyValue
+		expected sdktrace.SamplingDecision
+	}{
+		{"no url", nil, sdktrace.RecordAndSample},
+		{"unfiltered", []attribute.KeyValue{
+			attribute.String("url.path", "/api/users"),
+		}, sdktrace.RecordAndSample},
+		{"health check", []attribute.KeyValue{
+			attribute.String("server.address", "example.com"),
+			attribute.String("url.path", "/healthz"),
+		}, sdktrace.Drop},
+		{"static asset", []attribute.KeyValue{
+			attribute.String("http.target", "/static/logo.png?v=2"),
+		}, sdktrace.Drop},
+		{"full url", []attribute.KeyValue{
+			attribute.String("url.full", "https://example.com/img/logo.png"),
+		}, sdktrace.Drop},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			result := smplr.ShouldSample(sdktrace.SamplingParameters{
+				ParentContext: context.Background(),
+				TraceID:       traceId,
+				Attributes:    tc.attrs,
+			})
+			require.Equal(t, tc.expected, result.Decision)
+		})
+	}
+}
+
 type SamplingScenario struct {
 	// inputs
 	validTraceParent        bool
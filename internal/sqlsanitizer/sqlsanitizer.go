@@ -0,0 +1,282 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlsanitizer replaces the literals of SQL statements with
+// placeholders so that no sensitive values are reported.
+package sqlsanitizer
+
+import "strings"
+
+// The sanitization levels, as configured by SQLSanitize
+const (
+	// Disabled disables SQL sanitizing
+	Disabled = 0
+	// EnabledAuto determines from the database system whether double quoted
+	// text is a string literal (MySQL) or an identifier (PostgreSQL and others)
+	EnabledAuto = 1
+	// EnabledDropDoubleQuoted treats double quoted text as string literals
+	EnabledDropDoubleQuoted = 2
+	// EnabledKeepDoubleQuoted treats double quoted text as identifiers
+	EnabledKeepDoubleQuoted = 4
+)
+
+// placeholder replaces the literals
+const placeholder = '?'
+
+// Dialect defines the quoting rules of a database system
+type Dialect int
+
+const (
+	DialectUnknown Dialect = iota
+	// DialectMySQL uses double quotes for strings and backticks for
+	// identifiers, and supports backslash escapes in strings.
+	DialectMySQL
+	// DialectPostgreSQL uses double quotes for identifiers and supports
+	// dollar-quoted strings.
+	DialectPostgreSQL
+)
+
+// DialectFromDBSystem returns the dialect of the given `db.system` value
+func DialectFromDBSystem(system string) Dialect {
+	switch strings.ToLower(system) {
+	case "mysql", "mariadb", "tidb":
+		return DialectMySQL
+	case "postgresql", "cockroachdb":
+		return DialectPostgreSQL
+	default:
+		return DialectUnknown
+	}
+}
+
+// IsValidLevel checks if the sanitization level is valid
+func IsValidLevel(level int) bool {
+	switch level {
+	case Disabled, EnabledAuto, EnabledDropDoubleQuoted, EnabledKeepDoubleQuoted:
+		return true
+	default:
+		return false
+	}
+}
+
+// Sanitize replaces the string and numeric literals of the query with `?`
+// according to the sanitization level and the dialect. Identifiers, keywords,
+// comments and bind parameters are kept as they are. An unterminated string
+// literal is replaced up to the end of the query.
+func Sanitize(query string, level int, dialect Dialect) string {
+	if level == Disabled || !IsValidLevel(level) {
+		return query
+	}
+	dropDoubleQuoted := level == EnabledDropDoubleQuoted ||
+		(level == EnabledAuto && dialect == DialectMySQL)
+	backslashEscapes := dialect == DialectMySQL
+
+	var b strings.Builder
+	b.Grow(len(query))
+	n := len(query)
+	for i := 0; i < n; {
+		c := query[i]
+		switch {
+		case c == '-' && i+1 < n && query[i+1] == '-',
+			c == '#' && dialect == DialectMySQL:
+			// line comment
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = n
+			} else {
+				end += i
+			}
+			b.WriteString(query[i:end])
+			i = end
+		case c == '/' && i+1 < n && query[i+1] == '*':
+			// block comment
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				end = n
+			} else {
+				end += i + 4
+			}
+			b.WriteString(query[i:end])
+			i = end
+		case c == '\'':
+			i = skipQuoted(query, i, backslashEscapes)
+			b.WriteByte(placeholder)
+		case c == '"':
+			end := skipQuoted(query, i, backslashEscapes)
+			if dropDoubleQuoted {
+				b.WriteByte(placeholder)
+			} else {
+				b.WriteString(query[i:end])
+			}
+			i = end
+		case c == '`':
+			end := skipQuoted(query, i, false)
+			b.WriteString(query[i:end])
+			i = end
+		case c == '$' && dialect != DialectMySQL:
+			if tagEnd, ok := dollarTag(query, i); ok {
+				// dollar-quoted string
+				tag := query[i:tagEnd]
+				end := strings.Index(query[tagEnd:], tag)
+				if end < 0 {
+					end = n
+				} else {
+					end += tagEnd + len(tag)
+				}
+				b.WriteByte(placeholder)
+				i = end
+			} else {
+				// positional parameter, e.g. $1
+				end := skipIdent(query, i+1)
+				b.WriteString(query[i:end])
+				i = end
+			}
+		case c == ':':
+			// named parameter, e.g. :name, or a PostgreSQL cast, e.g. ::int
+			end := i + 1
+			if end < n && query[end] == ':' {
+				end++
+			} else {
+				end = skipIdent(query, end)
+			}
+			b.WriteString(query[i:end])
+			i = end
+		case isDigit(c) || (c == '.' && i+1 < n && isDigit(query[i+1])):
+			i = skipNumber(query, i)
+			b.WriteByte(placeholder)
+		case isIdentChar(c):
+			end := skipIdent(query, i)
+			if end < n && query[end] == '\'' && isStringPrefix(query[i:end]) {
+				// prefixed string, e.g. E'...', N'...' or X'...'
+				escapes := backslashEscapes || strings.EqualFold(query[i:end], "e")
+				i = skipQuoted(query, end, escapes)
+				b.WriteByte(placeholder)
+			} else {
+				b.WriteString(query[i:end])
+				i = end
+			}
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+// skipQuoted returns the index following the quoted text starting at i. The
+// quote is escaped by doubling it, or with a backslash if backslashEscapes.
+func skipQuoted(query string, i int, backslashEscapes bool) int {
+	quote := query[i]
+	n := len(query)
+	for j := i + 1; j < n; j++ {
+		switch query[j] {
+		case '\\':
+			if backslashEscapes {
+				j++
+			}
+		case quote:
+			if j+1 < n && query[j+1] == quote {
+				j++
+				continue
+			}
+			return j + 1
+		}
+	}
+	return n
+}
+
+// dollarTag returns the end of the dollar-quote tag, e.g. `$$` or `$tag$`,
+// starting at i.
+func dollarTag(query string, i int) (int, bool) {
+	j := i + 1
+	if j < len(query) && isDigit(query[j]) {
+		return 0, false
+	}
+	for j < len(query) && (isLetter(query[j]) || isDigit(query[j]) || query[j] == '_') {
+		j++
+	}
+	if j < len(query) && query[j] == '$' {
+		return j + 1, true
+	}
+	return 0, false
+}
+
+// skipNumber returns the index following the number starting at i
+func skipNumber(query string, i int) int {
+	n := len(query)
+	if query[i] == '0' && i+1 < n && (query[i+1] == 'x' || query[i+1] == 'X') {
+		j := i + 2
+		for j < n && isHexDigit(query[j]) {
+			j++
+		}
+		return j
+	}
+	j := i
+	for j < n && isDigit(query[j]) {
+		j++
+	}
+	if j < n && query[j] == '.' {
+		j++
+		for j < n && isDigit(query[j]) {
+			j++
+		}
+	}
+	if j < n && (query[j] == 'e' || query[j] == 'E') {
+		k := j + 1
+		if k < n && (query[k] == '+' || query[k] == '-') {
+			k++
+		}
+		if k < n && isDigit(query[k]) {
+			j = k
+			for j < n && isDigit(query[j]) {
+				j++
+			}
+		}
+	}
+	return j
+}
+
+// skipIdent returns the index following the identifier starting at i
+func skipIdent(query string, i int) int {
+	for i < len(query) && isIdentChar(query[i]) {
+		i++
+	}
+	return i
+}
+
+func isStringPrefix(word string) bool {
+	switch word {
+	case "E", "e", "N", "n", "X", "x", "B", "b":
+		return true
+	default:
+		return false
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isIdentChar returns if the byte can be part of an unquoted identifier or
+// keyword. Non-ASCII bytes are considered as letters.
+func isIdentChar(c byte) bool {
+	return isLetter(c) || isDigit(c) || c == '_' || c == '$' || c >= 0x80
+}
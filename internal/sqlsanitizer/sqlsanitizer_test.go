@@ -0,0 +1,99 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlsanitizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialectFromDBSystem(t *testing.T) {
+	assert.Equal(t, DialectMySQL, DialectFromDBSystem("mysql"))
+	assert.Equal(t, DialectMySQL, DialectFromDBSystem("MariaDB"))
+	assert.Equal(t, DialectPostgreSQL, DialectFromDBSystem("postgresql"))
+	assert.Equal(t, DialectPostgreSQL, DialectFromDBSystem("cockroachdb"))
+	assert.Equal(t, DialectUnknown, DialectFromDBSystem("mssql"))
+	assert.Equal(t, DialectUnknown, DialectFromDBSystem(""))
+}
+
+func TestSanitizeDisabled(t *testing.T) {
+	q := "SELECT * FROM users WHERE id = 1 AND name = 'bob'"
+	assert.Equal(t, q, Sanitize(q, Disabled, DialectMySQL))
+	// Invalid levels do not sanitize either
+	assert.Equal(t, q, Sanitize(q, 3, DialectMySQL))
+}
+
+func TestSanitize(t *testing.T) {
+	cases := []struct {
+		query    string
+		level    int
+		dialect  Dialect
+		expected string
+	}{
+		// numbers
+		{"SELECT * FROM t WHERE id = 42", EnabledAuto, DialectUnknown, "SELECT * FROM t WHERE id = ?"},
+		{"SELECT * FROM t WHERE a = -1.5 AND b = .5 AND c = 1e10 AND d = 2.5E-3", EnabledAuto, DialectUnknown,
+			"SELECT * FROM t WHERE a = -? AND b = ? AND c = ? AND d = ?"},
+		{"SELECT * FROM t WHERE a = 0xFF", EnabledAuto, DialectMySQL, "SELECT * FROM t WHERE a = ?"},
+		{"SELECT col1, t2.col_2 FROM table1 t1, table2 t2 LIMIT 10 OFFSET 20", EnabledAuto, DialectUnknown,
+			"SELECT col1, t2.col_2 FROM table1 t1, table2 t2 LIMIT ? OFFSET ?"},
+		{"INSERT INTO t (a, b) VALUES (1, 2), (3, 4)", EnabledAuto, DialectUnknown,
+			"INSERT INTO t (a, b) VALUES (?, ?), (?, ?)"},
+		// single quoted strings
+		{"SELECT * FROM t WHERE name = 'bob'", EnabledAuto, DialectUnknown, "SELECT * FROM t WHERE name = ?"},
+		{"SELECT * FROM t WHERE name = 'o''brien'", EnabledAuto, DialectPostgreSQL, "SELECT * FROM t WHERE name = ?"},
+		{`SELECT * FROM t WHERE name = 'it\'s' AND id = 1`, EnabledAuto, DialectMySQL, "SELECT * FROM t WHERE name = ? AND id = ?"},
+		{`SELECT * FROM t WHERE path = 'C:\' AND id = 1`, EnabledAuto, DialectPostgreSQL, "SELECT * FROM t WHERE path = ? AND id = ?"},
+		{"SELECT * FROM t WHERE name = 'unterminated", EnabledAuto, DialectUnknown, "SELECT * FROM t WHERE name = ?"},
+		{"SELECT * FROM t WHERE name = 'ünïcödé' AND b = 2", EnabledAuto, DialectUnknown, "SELECT * FROM t WHERE name = ? AND b = ?"},
+		// prefixed strings
+		{`SELECT E'a\'b', N'nat', X'1F', B'101'`, EnabledAuto, DialectPostgreSQL, "SELECT ?, ?, ?, ?"},
+		{"SELECT * FROM e WHERE x = 1", EnabledAuto, DialectPostgreSQL, "SELECT * FROM e WHERE x = ?"},
+		// double quotes
+		{`SELECT * FROM "Users" WHERE name = "bob"`, EnabledAuto, DialectMySQL, "SELECT * FROM ? WHERE name = ?"},
+		{`SELECT * FROM "Users" WHERE "Name" = 'bob'`, EnabledAuto, DialectPostgreSQL, `SELECT * FROM "Users" WHERE "Name" = ?`},
+		{`SELECT * FROM "Users" WHERE "Name" = 'bob'`, EnabledAuto, DialectUnknown, `SELECT * FROM "Users" WHERE "Name" = ?`},
+		{`SELECT * FROM "Users" WHERE name = "bob"`, EnabledDropDoubleQuoted, DialectPostgreSQL, "SELECT * FROM ? WHERE name = ?"},
+		{`SELECT * FROM "Users" WHERE name = "bob"`, EnabledKeepDoubleQuoted, DialectMySQL, `SELECT * FROM "Users" WHERE name = "bob"`},
+		{`SELECT "a""b" FROM t WHERE c = 1`, EnabledKeepDoubleQuoted, DialectUnknown, `SELECT "a""b" FROM t WHERE c = ?`},
+		// backticks
+		{"SELECT `col1` FROM `db`.`table1` WHERE `id` = 7", EnabledAuto, DialectMySQL, "SELECT `col1` FROM `db`.`table1` WHERE `id` = ?"},
+		// identifiers and parameters
+		{"SELECT * FROM t WHERE a = ? AND b = :name AND c = @p1", EnabledAuto, DialectUnknown,
+			"SELECT * FROM t WHERE a = ? AND b = :name AND c = @p1"},
+		{"SELECT * FROM t WHERE a = $1 AND b = $2", EnabledAuto, DialectPostgreSQL, "SELECT * FROM t WHERE a = $1 AND b = $2"},
+		{"SELECT id::text, '5'::int FROM t", EnabledAuto, DialectPostgreSQL, "SELECT id::text, ?::int FROM t"},
+		{"SELECT * FROM table_1 JOIN t2 ON table_1.id = t2.id", EnabledAuto, DialectUnknown,
+			"SELECT * FROM table_1 JOIN t2 ON table_1.id = t2.id"},
+		// dollar-quoted strings
+		{"SELECT $$it's a secret$$, $tag$with $$ inside$tag$ FROM t", EnabledAuto, DialectPostgreSQL, "SELECT ?, ? FROM t"},
+		{"SELECT $$unterminated", EnabledAuto, DialectPostgreSQL, "SELECT ?"},
+		// comments are kept
+		{"SELECT 1 -- user 'bob'\nFROM t", EnabledAuto, DialectUnknown, "SELECT ? -- user 'bob'\nFROM t"},
+		{"SELECT /* id = 5 */ a FROM t WHERE b = 6", EnabledAuto, DialectUnknown, "SELECT /* id = 5 */ a FROM t WHERE b = ?"},
+		{"SELECT a FROM t # id = 5", EnabledAuto, DialectMySQL, "SELECT a FROM t # id = 5"},
+		// statements other than SELECT
+		{"UPDATE accounts SET balance = balance - 100.00, note = 'refund' WHERE id = 12", EnabledAuto, DialectMySQL,
+			"UPDATE accounts SET balance = balance - ?, note = ? WHERE id = ?"},
+		{"DELETE FROM sessions WHERE expires < '2024-01-01' AND user_id IN (1, 2, 3)", EnabledAuto, DialectPostgreSQL,
+			"DELETE FROM sessions WHERE expires < ? AND user_id IN (?, ?, ?)"},
+		{"CALL proc('x', 1)", EnabledAuto, DialectMySQL, "CALL proc(?, ?)"},
+		{"", EnabledAuto, DialectUnknown, ""},
+	}
+	for i, c := range cases {
+		assert.Equal(t, c.expected, Sanitize(c.query, c.level, c.dialect), "case #%d: %s", i, c.query)
+	}
+}
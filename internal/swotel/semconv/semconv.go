@@ -15,11 +15,19 @@
 package semconv
 
 import (
+	"go.opentelemetry.io/otel/attribute"
 	otelconv25 "go.opentelemetry.io/otel/semconv/v1.25.0"
 	otelconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
 const (
+	DBQueryTextKey = otelconv.DBQueryTextKey
+	DBSystemKey    = otelconv.DBSystemKey
+
+	DBStatementKey = otelconv25.DBStatementKey // Deprecated in v1.26.0, use DBQueryTextKey instead
+	// DBSystemNameKey replaces DBSystemKey in v1.30.0
+	DBSystemNameKey = attribute.Key("db.system.name")
+
 	ExceptionEventName     = otelconv.ExceptionEventName
 	ExceptionMessageKey    = otelconv.ExceptionMessageKey
 	ExceptionTypeKey       = otelconv.ExceptionTypeKey
@@ -30,16 +38,28 @@ const (
 	HTTPRequestMethodKey = otelconv.HTTPRequestMethodKey
 	HTTPRouteKey         = otelconv.HTTPRouteKey
 	HTTPStatusCodeKey    = otelconv.HTTPResponseStatusCodeKey
+	URLFullKey           = otelconv.URLFullKey
 	URLPathKey           = otelconv.URLPathKey
+	URLQueryKey          = otelconv.URLQueryKey
+
+	ServerAddressKey = otelconv.ServerAddressKey
+	// HTTPHostKey was removed in v1.21.0, use ServerAddressKey instead
+	HTTPHostKey = attribute.Key("http.host")
 
 	HTTPTargetKey     = otelconv25.HTTPTargetKey     // Deprecated in v1.26.0, use URLPathKey instead
 	HTTPMethodKey     = otelconv25.HTTPMethodKey     // Deprecated in v1.26.0, use HTTPRequestMethodKey instead
 	HttpStatusCodeKey = otelconv25.HTTPStatusCodeKey // Deprecated in v1.26.0, use HTTPResponseStatusCodeKey instead
 
+	MessagingDestinationNameKey = otelconv.MessagingDestinationNameKey
+
+	RPCMethodKey  = otelconv.RPCMethodKey
+	RPCServiceKey = otelconv.RPCServiceKey
+
 	K8SNamespaceNameKey = otelconv.K8SNamespaceNameKey
 	K8SPodNameKey       = otelconv.K8SPodNameKey
 	K8SPodUIDKey        = otelconv.K8SPodUIDKey
 
+	OTelScopeNameKey         = otelconv.OTelScopeNameKey
 	OTelStatusDescriptionKey = otelconv.OTelStatusDescriptionKey
 
 	ServiceNameKey       = otelconv.ServiceNameKey
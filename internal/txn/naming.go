@@ -0,0 +1,208 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txn
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/solarwinds/apm-go/internal/log"
+)
+
+// OtherTransactionName is the name of the transactions exceeding the
+// cardinality limit
+const OtherTransactionName = "other"
+
+// idPlaceholder replaces the ID segments of the URL paths
+const idPlaceholder = ":id"
+
+var naming atomic.Pointer[namingRules]
+
+func init() {
+	ReloadNamingRules(config.GetTransactionNaming())
+}
+
+// ReloadNamingRules rebuilds the transaction naming rules and resets the
+// distinct transaction names seen so far.
+func ReloadNamingRules(cfg config.TransactionNamingConfig) {
+	naming.Store(newNamingRules(cfg))
+}
+
+// namingRules derives the transaction names from the URL paths
+type namingRules struct {
+	rules []namingRule
+	guard *nameGuard
+}
+
+type namingRule struct {
+	regex        *regexp.Regexp
+	name         string
+	segments     int
+	normalizeIDs bool
+}
+
+func newNamingRules(cfg config.TransactionNamingConfig) *namingRules {
+	n := &namingRules{guard: newNameGuard(cfg.MaxNames)}
+	for _, r := range cfg.Rules {
+		rule := namingRule{name: r.Name, segments: r.Segments, normalizeIDs: r.NormalizeIDs}
+		if r.RegEx != "" {
+			re, err := regexp.Compile(r.RegEx)
+			if err != nil {
+				log.Warningf("Ignore invalid transaction naming rule %+v: %s", r, err)
+				continue
+			}
+			rule.regex = re
+		}
+		n.rules = append(n.rules, rule)
+	}
+	return n
+}
+
+// nameFromPath derives the transaction name from the URL path with the first
+// matching rule, or from its first two segments if none matches.
+func (n *namingRules) nameFromPath(urlPath string) string {
+	// Drop query parameters and fragment
+	if idx := strings.IndexAny(urlPath, "?#"); idx != -1 {
+		urlPath = urlPath[:idx]
+	}
+
+	name := ""
+	matched := false
+	for _, r := range n.rules {
+		if name, matched = r.apply(urlPath); matched {
+			break
+		}
+	}
+	if !matched {
+		name = extractTransactionNameFromPath(urlPath)
+	}
+	return n.guard.check(name)
+}
+
+// apply returns the transaction name and true if the rule matches the path
+func (r namingRule) apply(urlPath string) (string, bool) {
+	var match []int
+	if r.regex != nil {
+		if match = r.regex.FindStringSubmatchIndex(urlPath); match == nil {
+			return "", false
+		}
+	}
+
+	name := urlPath
+	if r.name != "" {
+		if match != nil {
+			name = string(r.regex.ExpandString(nil, r.name, urlPath, match))
+		} else {
+			name = r.name
+		}
+	}
+	if r.normalizeIDs {
+		name = normalizeIDs(name)
+	}
+	if r.name == "" && r.segments > 0 {
+		name = firstSegments(name, r.segments)
+	}
+	return name, true
+}
+
+// firstSegments returns the first n segments of the path
+func firstSegments(urlPath string, n int) string {
+	segments := strings.SplitAfterN(strings.TrimPrefix(urlPath, "/"), "/", n+1)
+	if len(segments) > n {
+		segments = segments[:n]
+	}
+	name := strings.TrimSuffix(strings.Join(segments, ""), "/")
+	if strings.HasPrefix(urlPath, "/") {
+		name = "/" + name
+	}
+	return name
+}
+
+// normalizeIDs replaces the ID segments of the path with `:id`
+func normalizeIDs(urlPath string) string {
+	segments := strings.Split(urlPath, "/")
+	for i, s := range segments {
+		if isID(s) {
+			segments[i] = idPlaceholder
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// minHexIDLen is the minimum length of the hexadecimal IDs, e.g. object IDs
+// or hashes, so that words like `cafe` are not replaced
+const minHexIDLen = 16
+
+// isID returns if the path segment is a number, a UUID or a long
+// hexadecimal string
+func isID(s string) bool {
+	if s == "" {
+		return false
+	}
+	if _, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return true
+	}
+	if uuidRegex.MatchString(s) {
+		return true
+	}
+	if len(s) < minHexIDLen {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// nameGuard bounds the number of distinct transaction names. Once the limit
+// is reached, the names not seen yet are replaced with OtherTransactionName.
+type nameGuard struct {
+	limit int
+	mut   sync.Mutex
+	seen  map[string]struct{}
+}
+
+func newNameGuard(limit int) *nameGuard {
+	return &nameGuard{limit: limit, seen: make(map[string]struct{})}
+}
+
+func (g *nameGuard) check(name string) string {
+	if g.limit <= 0 {
+		return name
+	}
+	g.mut.Lock()
+	defer g.mut.Unlock()
+	if _, ok := g.seen[name]; ok {
+		return name
+	}
+	if len(g.seen) >= g.limit {
+		return OtherTransactionName
+	}
+	g.seen[name] = struct{}{}
+	if len(g.seen) == g.limit {
+		log.Warningf("The limit of %d distinct transaction names is reached, "+
+			"the requests with a new URL path are named %q", g.limit, OtherTransactionName)
+	}
+	return name
+}
@@ -0,0 +1,115 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/solarwinds/apm-go/internal/swotel/semconv"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestNamingRules(t *testing.T) {
+	n := newNamingRules(config.TransactionNamingConfig{
+		Rules: []config.TransactionNamingRule{
+			{RegEx: `^/api/v(\d+)/(\w+)`, Name: "/api/v${1}/${2}"},
+			{RegEx: `^/files/`, Segments: 1},
+			{RegEx: `^/shop/`, Segments: 3, NormalizeIDs: true},
+			{RegEx: `^/static/`, Name: "static"},
+			{RegEx: `^/users/`, NormalizeIDs: true},
+		},
+	})
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/api/v1/users/123", "/api/v1/users"},
+		{"/api/v1/orders?id=1", "/api/v1/orders"},
+		{"/api/v2/users/123/details", "/api/v2/users"},
+		{"/files/a/b/c.txt", "/files"},
+		{"/shop/42/items/7/reviews", "/shop/:id/items"},
+		{"/static/css/main.css", "static"},
+		{"/users/123", "/users/:id"},
+		{"/users/0b3c4f1e-9a2d-4c57-8e3b-2f1d6a7c9e10/posts/507f1f77bcf86cd799439011", "/users/:id/posts/:id"},
+		{"/users/cafe/posts", "/users/cafe/posts"},
+		// No rule matches: the first two segments are used
+		{"/other/path/here", "/other/path"},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, n.nameFromPath(tt.path), tt.path)
+	}
+}
+
+func TestFirstSegments(t *testing.T) {
+	require.Equal(t, "/a", firstSegments("/a/b/c", 1))
+	require.Equal(t, "/a/b", firstSegments("/a/b/c", 2))
+	require.Equal(t, "/a/b/c", firstSegments("/a/b/c", 5))
+	require.Equal(t, "/a", firstSegments("/a/", 2))
+	require.Equal(t, "a/b", firstSegments("a/b/c", 2))
+}
+
+func TestIsID(t *testing.T) {
+	for _, s := range []string{"1", "12345", "0B3C4F1E-9A2D-4C57-8E3B-2F1D6A7C9E10", "507f1f77bcf86cd799439011"} {
+		require.True(t, isID(s), s)
+	}
+	for _, s := range []string{"", "users", "v1", "cafe", "-1", "12ab", "0b3c4f1e-9a2d"} {
+		require.False(t, isID(s), s)
+	}
+}
+
+func TestNameGuard(t *testing.T) {
+	g := newNameGuard(2)
+	require.Equal(t, "/a", g.check("/a"))
+	require.Equal(t, "/b", g.check("/b"))
+	require.Equal(t, OtherTransactionName, g.check("/c"))
+	// The names seen before the limit was reached are kept
+	require.Equal(t, "/a", g.check("/a"))
+	require.Equal(t, OtherTransactionName, g.check("/d"))
+
+	unlimited := newNameGuard(0)
+	for i := range 100 {
+		name := fmt.Sprintf("/%d", i)
+		require.Equal(t, name, unlimited.check(name))
+	}
+}
+
+func TestDeriveTransactionName_NamingRules(t *testing.T) {
+	config.Load(config.WithTransactionNaming(config.TransactionNamingConfig{
+		Rules:    []config.TransactionNamingRule{{RegEx: `^/users/`, NormalizeIDs: true}},
+		MaxNames: 2,
+	}))
+	ReloadNamingRules(config.GetTransactionNaming())
+	t.Cleanup(func() {
+		config.Load()
+		ReloadNamingRules(config.GetTransactionNaming())
+	})
+
+	derive := func(path string) string {
+		return deriveTransactionName("span", []attribute.KeyValue{semconv.URLPathKey.String(path)})
+	}
+	require.Equal(t, "/users/:id/posts", derive("/users/1/posts"))
+	require.Equal(t, "/users/:id/posts", derive("/users/2/posts"))
+	require.Equal(t, "/api/v1", derive("/api/v1/users"))
+	require.Equal(t, OtherTransactionName, derive("/api/v2/users"))
+
+	// The routes are not limited
+	require.Equal(t, "/api/v2/users", deriveTransactionName("span", []attribute.KeyValue{
+		semconv.HTTPRouteKey.String("/api/v2/users"),
+	}))
+}
@@ -15,6 +15,8 @@
 package txn
 
 import (
+	"context"
+	"net"
 	"os"
 	"strings"
 
@@ -23,6 +25,7 @@ import (
 	"github.com/solarwinds/apm-go/internal/swotel/semconv"
 	"go.opentelemetry.io/otel/attribute"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // GetTransactionName retrieves the custom transaction name if it exists, otherwise calls deriveTransactionName
@@ -34,6 +37,29 @@ func GetTransactionName(span sdktrace.ReadOnlySpan) string {
 	}
 }
 
+// CurrentTransactionName returns the transaction name of the entry span of
+// the trace in ctx, falling back to the span in ctx when the entry spans are
+// not tracked, e.g. in Lambda. It returns an empty string if ctx holds no span.
+func CurrentTransactionName(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return ""
+	}
+	if span, ok := entryspans.CurrentSpan(sc.TraceID()); ok {
+		return GetTransactionName(span)
+	}
+	if span, ok := trace.SpanFromContext(ctx).(sdktrace.ReadOnlySpan); ok {
+		return GetTransactionName(span)
+	}
+	return ""
+}
+
+// DeriveTransactionName returns the transaction name derived from the span
+// name and attributes, e.g. at sampling time when the span is not created yet.
+func DeriveTransactionName(spanName string, attrs []attribute.KeyValue) string {
+	return deriveTransactionName(spanName, attrs)
+}
+
 // deriveTransactionName returns transaction name from given span name and attributes, falling back to "unknown"
 func deriveTransactionName(spanName string, attrs []attribute.KeyValue) string {
 	// First priority: Check configuration
@@ -48,7 +74,7 @@ func deriveTransactionName(spanName string, attrs []attribute.KeyValue) string {
 
 	// Third priority: Derive from span attributes
 	if txnName == "" {
-		var faasName, httpRoute, urlPath string
+		var faasName, httpRoute, urlPath, serverAddress, httpHost string
 		for _, attr := range attrs {
 			switch attr.Key {
 			case semconv.FaaSNameKey:
@@ -59,6 +85,10 @@ func deriveTransactionName(spanName string, attrs []attribute.KeyValue) string {
 				urlPath = attr.Value.AsString()
 			case semconv.HTTPTargetKey:
 				urlPath = attr.Value.AsString()
+			case semconv.ServerAddressKey:
+				serverAddress = attr.Value.AsString()
+			case semconv.HTTPHostKey:
+				httpHost = attr.Value.AsString()
 			}
 		}
 
@@ -68,7 +98,16 @@ func deriveTransactionName(spanName string, attrs []attribute.KeyValue) string {
 		} else if httpRoute != "" {
 			txnName = httpRoute
 		} else if urlPath != "" {
-			txnName = extractTransactionNameFromPath(urlPath)
+			txnName = naming.Load().nameFromPath(urlPath)
+		}
+
+		// Prepend the domain to the HTTP transaction names, server.address is
+		// preferred over the deprecated http.host
+		if faasName == "" && txnName != "" && txnName != OtherTransactionName && config.GetPrependDomain() {
+			if serverAddress == "" {
+				serverAddress = httpHost
+			}
+			txnName = prependDomain(serverAddress, txnName)
 		}
 	}
 
@@ -89,6 +128,21 @@ func deriveTransactionName(spanName string, attrs []attribute.KeyValue) string {
 	return txnName
 }
 
+// prependDomain prepends the domain, without the port, to the transaction name
+func prependDomain(domain string, txnName string) string {
+	domain = strings.TrimSpace(domain)
+	if host, _, err := net.SplitHostPort(domain); err == nil {
+		domain = host
+	}
+	if domain == "" {
+		return txnName
+	}
+	if !strings.HasPrefix(txnName, "/") {
+		return domain + "/" + txnName
+	}
+	return domain + txnName
+}
+
 // extractTransactionNameFromPath extracts up to 2 path segments from a URL path,
 // ignoring query parameters and fragments
 func extractTransactionNameFromPath(urlPath string) string {
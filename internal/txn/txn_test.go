@@ -44,6 +44,23 @@ func TestGetTransactionWhenUpdatedWithAPI(t *testing.T) {
 	require.Equal(t, "custom", GetTransactionName(roSpan))
 }
 
+func TestCurrentTransactionName(t *testing.T) {
+	tr, teardown := testutils.TracerSetup()
+	defer teardown()
+
+	require.Equal(t, "", CurrentTransactionName(context.Background()))
+
+	ctx, entry := tr.Start(context.Background(), "entry")
+	require.NoError(t, entryspans.Push(entry.(trace.ReadWriteSpan)))
+	defer func() { require.NoError(t, entryspans.Delete(entry.(trace.ReadOnlySpan))) }()
+	require.Equal(t, "entry", CurrentTransactionName(ctx))
+
+	childCtx, child := tr.Start(ctx, "child")
+	defer child.End()
+	require.NoError(t, entryspans.SetTransactionName(entry.SpanContext().TraceID(), "custom"))
+	require.Equal(t, "custom", CurrentTransactionName(childCtx))
+}
+
 func TestTransactionNamePrecedenceOrder(t *testing.T) {
 	// Priority order:
 	// 1. config.GetTransactionName() (SW_APM_TRANSACTION_NAME in Lambda)
@@ -228,3 +245,72 @@ func TestDeriveTransactionName_ConfigIntegration(t *testing.T) {
 		require.Equal(t, "my-lambda", deriveTransactionName("span name", nil))
 	})
 }
+
+func TestDeriveTransactionName_PrependDomain(t *testing.T) {
+	config.Load(func(c *config.Config) { c.PrependDomain = true })
+	t.Cleanup(func() { config.Load() })
+
+	tests := []struct {
+		name     string
+		spanName string
+		attrs    []attribute.KeyValue
+		expected string
+	}{
+		{
+			name: "server.address with http.route",
+			attrs: []attribute.KeyValue{
+				semconv.ServerAddressKey.String("api.example.com"),
+				semconv.HTTPRouteKey.String("/v1/users"),
+			},
+			expected: "api.example.com/v1/users",
+		},
+		{
+			name: "server.address beats http.host",
+			attrs: []attribute.KeyValue{
+				semconv.HTTPHostKey.String("old.example.com"),
+				semconv.ServerAddressKey.String("api.example.com"),
+				semconv.URLPathKey.String("/v1/users/123"),
+			},
+			expected: "api.example.com/v1/users",
+		},
+		{
+			name: "http.host without the port",
+			attrs: []attribute.KeyValue{
+				semconv.HTTPHostKey.String("api.example.com:8080"),
+				semconv.HTTPTargetKey.String("/v1/users?id=1"),
+			},
+			expected: "api.example.com/v1/users",
+		},
+		{
+			name:     "no domain",
+			attrs:    []attribute.KeyValue{semconv.HTTPRouteKey.String("/v1/users")},
+			expected: "/v1/users",
+		},
+		{
+			name: "not prepended to faas.name",
+			attrs: []attribute.KeyValue{
+				semconv.FaaSNameKey.String("my-func"),
+				semconv.ServerAddressKey.String("api.example.com"),
+			},
+			expected: "my-func",
+		},
+		{
+			name:     "not prepended to the span name",
+			spanName: "my-span",
+			attrs:    []attribute.KeyValue{semconv.ServerAddressKey.String("api.example.com")},
+			expected: "my-span",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, deriveTransactionName(tt.spanName, tt.attrs))
+		})
+	}
+
+	// Disabled by default
+	config.Load()
+	require.Equal(t, "/v1/users", deriveTransactionName("", []attribute.KeyValue{
+		semconv.ServerAddressKey.String("api.example.com"),
+		semconv.HTTPRouteKey.String("/v1/users"),
+	}))
+}
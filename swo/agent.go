@@ -27,9 +27,12 @@ import (
 	"github.com/solarwinds/apm-go/internal/propagator"
 	"github.com/solarwinds/apm-go/internal/reporter"
 	"github.com/solarwinds/apm-go/internal/sampler"
+	"github.com/solarwinds/apm-go/internal/sqlsanitizer"
 	"github.com/solarwinds/apm-go/internal/state"
+	"github.com/solarwinds/apm-go/internal/txn"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -39,12 +42,28 @@ import (
 // Start bootstraps otel requirements and starts the agent. The given `resourceAttrs` are added to the otel
 // `resource.Resource` that is supplied to the otel `TracerProvider`
 func Start(resourceAttrs ...attribute.KeyValue) (func(), error) {
+	return StartWithOptions(WithResourceAttributes(resourceAttrs...))
+}
+
+// StartWithOptions bootstraps otel requirements and starts the agent configured by the given options.
+// Options take precedence over the config file and the environment variables.
+func StartWithOptions(opts ...Option) (func(), error) {
+	options := newOptions(opts...)
+	if options.logWriter != nil {
+		log.SetOutput(options.logWriter)
+	}
+	config.LoadAndLog(options.configOpts...)
+	oboe.ReloadURLsConfig(config.GetTransactionFiltering())
+	txn.ReloadNamingRules(config.GetTransactionNaming())
+	log.SetFormatFromStr(config.LogFormat())
+	log.SetRateLimitInterval(config.LogRateLimitInterval())
+
 	if !config.GetEnabled() {
 		log.Info("SolarWinds Observability APM agent is disabled, skipping startup.")
 		return func() {}, nil
 	}
 
-	resrc, err := createResource(resourceAttrs...)
+	resrc, err := createResource(options.resourceAttrs...)
 	if err != nil {
 		return func() {
 			// return a no-op func so that we don't cause a nil-deref for the end-user
@@ -55,7 +74,7 @@ func Start(resourceAttrs ...attribute.KeyValue) (func(), error) {
 	svcName := serviceNameFromResource(resrc)
 	state.SetServiceName(svcName)
 
-	o := oboe.NewOboe()
+	o := oboe.NewOboeWithStrategy(options.samplingStrategy)
 	setGlobalOboe(o)
 	settingsUpdater, err := oboe.NewSettingsUpdater(o, svcName)
 	if err != nil {
@@ -63,13 +82,18 @@ func Start(resourceAttrs ...attribute.KeyValue) (func(), error) {
 		return func() {}, err
 	}
 
+	setDiagnosticsState(settingsUpdater, resrc)
+
 	ctx := context.Background()
 	stopSettingsUpdater := settingsUpdater.Start(ctx)
 
-	exprtr, err := otelsetup.NewSpanExporter(ctx)
-	if err != nil {
-		log.Error("Failed to configure span exporter, ", err)
-		return func() { stopSettingsUpdater() }, err
+	exprtr := options.spanExporter
+	if exprtr == nil {
+		exprtr, err = otelsetup.NewSpanExporter(ctx)
+		if err != nil {
+			log.Error("Failed to configure span exporter, ", err)
+			return func() { stopSettingsUpdater() }, err
+		}
 	}
 
 	metricsPublisher := reporter.NewMetricsPublisher()
@@ -83,6 +107,7 @@ func Start(resourceAttrs ...attribute.KeyValue) (func(), error) {
 	if err != nil {
 		return func() { stopSettingsUpdater() }, err
 	}
+	setGlobalCustomMetrics(metricsPublisher.GetCustomMetrics())
 
 	proc := processor.NewInboundMetricsSpanProcessor(metricsPublisher.GetMetricsRegistry())
 	prop := propagation.NewCompositeTextMapPropagator(
@@ -91,16 +116,31 @@ func Start(resourceAttrs ...attribute.KeyValue) (func(), error) {
 		&propagator.SolarwindsPropagator{},
 	)
 	otel.SetTextMapPropagator(prop)
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exprtr),
+	if options.errorHandler {
+		otel.SetErrorHandler(otelsetup.ErrorHandler())
+	}
+	tpOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithSpanProcessor(wrapExportProcessor(sdktrace.NewBatchSpanProcessor(exprtr), metricsPublisher.GetMeterProvider())),
 		sdktrace.WithResource(resrc),
 		sdktrace.WithSampler(smplr),
 		sdktrace.WithSpanProcessor(proc),
-	)
+		sdktrace.WithSpanProcessor(processor.NewOutboundMetricsSpanProcessor(metricsPublisher.GetOutboundMetricsRegistry())),
+	}
+	for _, sp := range options.spanProcessors {
+		tpOpts = append(tpOpts, sdktrace.WithSpanProcessor(sp))
+	}
+	tp := sdktrace.NewTracerProvider(tpOpts...)
 	otel.SetTracerProvider(tp)
 
+	stopConfigWatcher := config.WatchConfigFile(func(delta *config.Delta) {
+		applyReloadedConfig(o, delta)
+	}, options.configOpts...)
+
 	return func() {
+		stopConfigWatcher()
 		setGlobalOboe(nil)
+		setGlobalCustomMetrics(nil)
+		setDiagnosticsState(nil, nil)
 		stopSettingsUpdater()
 
 		err := metricsPublisher.Shutdown()
@@ -113,6 +153,46 @@ func Start(resourceAttrs ...attribute.KeyValue) (func(), error) {
 	}, nil
 }
 
+// wrapExportProcessor wraps the exporting span processor with the processors
+// which rewrite the spans before export: the database statements are sanitized
+// according to the SQLSanitize level and the sensitive attributes are redacted.
+// The traces which are not sampled are buffered for tail sampling. The spans
+// matching the span filters are dropped first, and counted with the meter
+// provider mp.
+func wrapExportProcessor(proc sdktrace.SpanProcessor, mp metric.MeterProvider) sdktrace.SpanProcessor {
+	if level := config.GetSQLSanitize(); level != sqlsanitizer.Disabled {
+		proc = processor.NewSQLSanitizeSpanProcessor(proc, level)
+	}
+	redaction := config.GetRedaction()
+	if reportQueryString := config.GetReportQueryString(); !redaction.IsEmpty() || !reportQueryString {
+		proc = processor.NewRedactionSpanProcessor(proc, redaction, reportQueryString)
+	}
+	if tail := config.GetTailSampling(); tail.Enabled {
+		proc = processor.NewTailSamplingProcessor(proc, tail)
+	}
+	if filters := config.GetSpanFilters(); len(filters) > 0 {
+		proc = processor.NewSpanFilterProcessor(proc, filters, mp)
+	}
+	return proc
+}
+
+// applyReloadedConfig applies the settings changed when the config file is
+// reloaded
+func applyReloadedConfig(o oboe.Oboe, delta *config.Delta) {
+	if delta.Changed("TransactionSettings") {
+		oboe.ReloadURLsConfig(config.GetTransactionFiltering())
+	}
+	if delta.Changed("Sampling") || delta.Changed("TransactionSettings") {
+		o.ReloadLocalSettings()
+	}
+	if delta.Changed("DebugLevel") {
+		log.SetLevelFromStr(config.DebugLevel())
+	}
+	if delta.Changed("LogRateLimitInterval") {
+		log.SetRateLimitInterval(config.LogRateLimitInterval())
+	}
+}
+
 func serviceNameFromResource(resrc *resource.Resource) string {
 	val, _ := resrc.Set().Value(semconv.ServiceNameKey)
 	return val.AsString()
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"log/slog"
 	"strings"
 	"time"
 
@@ -52,6 +53,15 @@ func SetLogOutput(w io.Writer) {
 	log.SetOutput(w)
 }
 
+// SetLogHandler routes the logs of the library to the slog handler h, e.g.
+// the one of the application, rather than to the output set by SetLogOutput.
+// The records carry the `component` of the library logging them, e.g.
+// `sampler` or `settings`, and the `error` they report as attributes. The
+// level set by SetLogLevel still applies. A nil handler restores the output.
+func SetLogHandler(h slog.Handler) {
+	log.SetHandler(h)
+}
+
 // WaitForReady checks if the library is ready to sample requests. It returns
 // true if ready or if the library is disabled, else it returns false.
 // This is a blocking call with default timeout of 10 seconds which can be
@@ -18,6 +18,7 @@ import (
 	"context"
 	"os"
 
+	"github.com/solarwinds/apm-go/internal/config"
 	"github.com/solarwinds/apm-go/internal/log"
 	"github.com/solarwinds/apm-go/internal/metrics"
 	"github.com/solarwinds/apm-go/internal/oboe"
@@ -59,6 +60,7 @@ func StartLambda(lambdaLogStreamName string) (Flusher, error) {
 			log.Warningf("could not override unset OTEL_EXPORTER_OTLP_ENDPOINT %s", err)
 		}
 	}
+	config.LoadAndLog()
 	ctx := context.Background()
 	o := oboe.NewOboe()
 	settingsWatcher := oboe.NewFileBasedWatcher(o)
@@ -81,6 +83,7 @@ func StartLambda(lambdaLogStreamName string) (Flusher, error) {
 	}
 	mp := metric.NewMeterProvider(
 		metric.WithReader(reader),
+		metric.WithView(metrics.ResponseTimeViews()...),
 	)
 	otel.SetMeterProvider(mp)
 	if err = o.RegisterOtelSampleRateMetrics(mp); err != nil {
@@ -90,13 +93,17 @@ func StartLambda(lambdaLogStreamName string) (Flusher, error) {
 		return nil, err
 	} else {
 		// Use WithSyncer to flush all spans each invocation
-		tpOpts = append(tpOpts, sdktrace.WithSyncer(exprtr))
+		tpOpts = append(tpOpts, sdktrace.WithSpanProcessor(wrapExportProcessor(sdktrace.NewSimpleSpanProcessor(exprtr), mp)))
 	}
 	registry, err := metrics.NewOtelRegistry(mp)
 	if err != nil {
 		return nil, err
 	}
 	proc := processor.NewInboundMetricsSpanProcessor(registry)
+	outboundRegistry, err := metrics.NewOtelOutboundRegistry(mp)
+	if err != nil {
+		return nil, err
+	}
 	prop := propagation.NewCompositeTextMapPropagator(
 		&propagation.TraceContext{},
 		&propagation.Baggage{},
@@ -125,7 +132,9 @@ func StartLambda(lambdaLogStreamName string) (Flusher, error) {
 		sdktrace.WithResource(resrc),
 		sdktrace.WithSampler(smplr),
 		sdktrace.WithSpanProcessor(proc),
+		sdktrace.WithSpanProcessor(processor.NewOutboundMetricsSpanProcessor(outboundRegistry)),
 	)
 	otel.SetTracerProvider(sdktrace.NewTracerProvider(tpOpts...))
+	setGlobalCustomMetrics(metrics.NewCustomMetrics(mp))
 	return flusher, nil
 }
@@ -16,15 +16,20 @@ package swo
 
 import (
 	"context"
+	"encoding/json"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/solarwinds/apm-go/internal/config"
 	"github.com/solarwinds/apm-go/internal/entryspans"
 	"github.com/solarwinds/apm-go/internal/log"
+	"github.com/solarwinds/apm-go/internal/oboe"
 	"github.com/solarwinds/apm-go/internal/state"
 	"github.com/solarwinds/apm-go/internal/testutils"
 	"github.com/solarwinds/apm-go/internal/utils"
@@ -68,6 +73,24 @@ func TestSetLogOutput(t *testing.T) {
 	assert.True(t, strings.Contains(buf.String(), "hello world"))
 }
 
+func TestSetLogHandler(t *testing.T) {
+	oldLevel := GetLogLevel()
+	require.NoError(t, SetLogLevel("INFO"))
+	var buf utils.SafeBuffer
+	SetLogHandler(slog.NewJSONHandler(&buf, nil))
+	defer func() {
+		SetLogHandler(nil)
+		require.NoError(t, SetLogLevel(oldLevel))
+	}()
+
+	log.Info("hello world")
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(buf.String()), &record))
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, "hello world", record["msg"])
+	assert.Equal(t, "solarwinds_apm", record["logger"])
+}
+
 func TestCreateResource(t *testing.T) {
 	r, err := createResource(attribute.String("foo", "bar"))
 	require.NoError(t, err)
@@ -209,3 +232,31 @@ func TestStartDisabled(t *testing.T) {
 	// The returned shutdown func must be a no-op (not nil) and must not panic.
 	cb()
 }
+
+func TestApplyReloadedConfig(t *testing.T) {
+	oldLevel := GetLogLevel()
+	path := filepath.Join(t.TempDir(), "solarwinds-apm-goagent.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("DebugLevel: info\n"), 0644))
+	t.Setenv("SW_APM_CONFIG_FILE", path)
+	config.Load()
+	t.Cleanup(func() {
+		require.NoError(t, SetLogLevel(oldLevel))
+		os.Unsetenv("SW_APM_CONFIG_FILE")
+		config.Load()
+	})
+
+	require.NoError(t, os.WriteFile(path, []byte("DebugLevel: debug\n"), 0644))
+	delta, err := config.Reload()
+	require.NoError(t, err)
+	require.True(t, delta.Changed("DebugLevel"))
+
+	applyReloadedConfig(oboe.NewOboe(), delta)
+	assert.Equal(t, "DEBUG", GetLogLevel())
+
+	require.NoError(t, os.WriteFile(path, []byte("DebugLevel: debug\nLogRateLimitInterval: 60\n"), 0644))
+	delta, err = config.Reload()
+	require.NoError(t, err)
+	require.True(t, delta.Changed("LogRateLimitInterval"))
+	assert.Equal(t, time.Minute, config.LogRateLimitInterval())
+	applyReloadedConfig(oboe.NewOboe(), delta)
+}
@@ -0,0 +1,75 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swo
+
+import (
+	"github.com/solarwinds/apm-go/internal/config"
+)
+
+// ConfigSource is where the effective value of a setting comes from
+type ConfigSource string
+
+const (
+	// ConfigSourceDefault is the default value of the setting
+	ConfigSourceDefault = ConfigSource(config.SourceDefault)
+	// ConfigSourceFile is the config file or the file overriding it
+	ConfigSourceFile = ConfigSource(config.SourceFile)
+	// ConfigSourceEnv is an environment variable
+	ConfigSourceEnv = ConfigSource(config.SourceEnv)
+	// ConfigSourceOption is an option given to ValidateConfig
+	ConfigSourceOption = ConfigSource(config.SourceOption)
+)
+
+var (
+	// ErrUnknownConfigKey is reported for the keys of the config file which
+	// don't match any setting
+	ErrUnknownConfigKey = config.ErrUnknownKey
+	// ErrInvalidConfigValue is reported for the values the library rejects,
+	// which are replaced with the default values or ignored
+	ErrInvalidConfigValue = config.ErrInvalidValue
+)
+
+// ConfigEntry is the effective value of a setting
+type ConfigEntry struct {
+	// Key is the dotted path of the setting, e.g. `Sampling.SampleRate`
+	Key string
+	// Value is the effective value, the service key and the proxy password
+	// are masked
+	Value  string
+	Source ConfigSource
+	// Origin is the path of the file or the name of the environment variable
+	// setting the value, if any
+	Origin string
+}
+
+// ValidateConfig loads the configuration the same way as StartWithOptions,
+// from the config file, the environment variables and the options, without
+// starting the library. It returns the effective value and the source of
+// every setting, and an error joining all the problems found, e.g. the
+// unknown keys of the config file (ErrUnknownConfigKey) or the invalid values
+// (ErrInvalidConfigValue), which the library would otherwise only log.
+func ValidateConfig(opts ...Option) ([]ConfigEntry, error) {
+	entries, err := config.Explain(newOptions(opts...).configOpts...)
+	converted := make([]ConfigEntry, 0, len(entries))
+	for _, e := range entries {
+		converted = append(converted, ConfigEntry{
+			Key:    e.Key,
+			Value:  e.Value,
+			Source: ConfigSource(e.Source),
+			Origin: e.Origin,
+		})
+	}
+	return converted, err
+}
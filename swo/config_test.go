@@ -0,0 +1,48 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "solarwinds-apm-goagent.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("DebugLevel: info\nUnknown: 1\n"), 0644))
+	t.Setenv("SW_APM_CONFIG_FILE", path)
+	t.Setenv("SW_APM_SERVICE_KEY", "ae38315f6116585d64d82ec2455aa3ec61e02fee25d286f74ace9e4fea189217:go")
+
+	entries, err := ValidateConfig(WithSampleRate(2000000))
+	require.ErrorIs(t, err, ErrUnknownConfigKey)
+	require.ErrorIs(t, err, ErrInvalidConfigValue)
+
+	bySetting := map[string]ConfigEntry{}
+	for _, e := range entries {
+		bySetting[e.Key] = e
+	}
+	assert.Equal(t, ConfigEntry{Key: "DebugLevel", Value: "info", Source: ConfigSourceFile, Origin: path}, bySetting["DebugLevel"])
+	assert.Equal(t, ConfigSourceEnv, bySetting["ServiceKey"].Source)
+	assert.Equal(t, ConfigEntry{Key: "Sampling.SampleRate", Value: "1000000", Source: ConfigSourceOption}, bySetting["Sampling.SampleRate"])
+	assert.Equal(t, ConfigSourceDefault, bySetting["Collector"].Source)
+
+	require.NoError(t, os.WriteFile(path, []byte("DebugLevel: info\n"), 0644))
+	_, err = ValidateConfig()
+	require.NoError(t, err)
+}
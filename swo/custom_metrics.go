@@ -0,0 +1,99 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swo
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/solarwinds/apm-go/internal/metrics"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var (
+	// ErrInvalidMetricName is returned when the metric name doesn't follow
+	// the OpenTelemetry instrument name syntax
+	ErrInvalidMetricName = metrics.ErrInvalidMetricName
+	// ErrInvalidMetricValue is returned when the count of IncrementMetric is
+	// negative or the value of SummaryMetric is NaN or infinite
+	ErrInvalidMetricValue = metrics.ErrInvalidMetricValue
+	// ErrMetricKindConflict is returned when the metric name is already used
+	// by a metric of the other kind, e.g. a summary named like a counter
+	ErrMetricKindConflict = metrics.ErrMetricKindConflict
+	// ErrTooManyAttributes is returned when a measurement has more than
+	// MaxMetricAttributes attributes
+	ErrTooManyAttributes = metrics.ErrTooManyAttributes
+	// ErrTooManyMetrics is returned when more than MaxMetrics distinct
+	// custom metrics are reported
+	ErrTooManyMetrics = metrics.ErrTooManyMetrics
+
+	errMetricsNotStarted = errors.New("custom metrics are not available, the library is not started")
+)
+
+const (
+	// MaxMetricAttributes is the maximum number of attributes of a custom
+	// metric measurement
+	MaxMetricAttributes = metrics.MaxCustomMetricAttributes
+	// MaxMetrics is the maximum number of distinct custom metrics
+	MaxMetrics = metrics.MaxCustomMetrics
+)
+
+// globalCustomMetrics holds the custom metrics recorded with the meter
+// provider of the agent after Start() or StartLambda() is called.
+var (
+	globalCustomMetricsMu sync.RWMutex
+	globalCustomMetrics   *metrics.CustomMetrics
+)
+
+// setGlobalCustomMetrics stores the custom metrics. Pass nil to clear them on shutdown.
+func setGlobalCustomMetrics(m *metrics.CustomMetrics) {
+	globalCustomMetricsMu.Lock()
+	defer globalCustomMetricsMu.Unlock()
+	globalCustomMetrics = m
+}
+
+// getGlobalCustomMetrics returns the custom metrics set by the most recent Start(), or nil if unset/shutdown.
+func getGlobalCustomMetrics() *metrics.CustomMetrics {
+	globalCustomMetricsMu.RLock()
+	defer globalCustomMetricsMu.RUnlock()
+	return globalCustomMetrics
+}
+
+// IncrementMetric adds count to the custom counter metric named name. The
+// measurement carries the given attributes and the transaction name of the
+// trace in ctx, if any. It returns an error if the library is not started,
+// the name is invalid or used by a summary, count is negative or there are too
+// many attributes.
+func IncrementMetric(ctx context.Context, name string, count int64, attrs ...attribute.KeyValue) error {
+	m := getGlobalCustomMetrics()
+	if m == nil {
+		return errMetricsNotStarted
+	}
+	return m.Increment(ctx, name, count, attrs...)
+}
+
+// SummaryMetric records value in the custom histogram metric named name. The
+// measurement carries the given attributes and the transaction name of the
+// trace in ctx, if any. It returns an error if the library is not started,
+// the name or the value, NaN or infinite, is invalid, the name is used by a
+// counter or there are too many attributes.
+func SummaryMetric(ctx context.Context, name string, value float64, attrs ...attribute.KeyValue) error {
+	m := getGlobalCustomMetrics()
+	if m == nil {
+		return errMetricsNotStarted
+	}
+	return m.Summary(ctx, name, value, attrs...)
+}
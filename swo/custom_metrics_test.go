@@ -0,0 +1,43 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swo
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/solarwinds/apm-go/internal/metrics"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+func TestCustomMetricsNotStarted(t *testing.T) {
+	require.Nil(t, getGlobalCustomMetrics())
+	require.Error(t, IncrementMetric(context.Background(), "count", 1))
+	require.Error(t, SummaryMetric(context.Background(), "amount", 1))
+}
+
+func TestCustomMetrics(t *testing.T) {
+	setGlobalCustomMetrics(metrics.NewCustomMetrics(sdkmetric.NewMeterProvider()))
+	t.Cleanup(func() { setGlobalCustomMetrics(nil) })
+
+	require.NoError(t, IncrementMetric(context.Background(), "count", 1))
+	require.NoError(t, SummaryMetric(context.Background(), "amount", 1.5))
+	require.ErrorIs(t, IncrementMetric(context.Background(), "bad name", 1), ErrInvalidMetricName)
+	require.ErrorIs(t, IncrementMetric(context.Background(), "count", -1), ErrInvalidMetricValue)
+	require.ErrorIs(t, SummaryMetric(context.Background(), "amount", math.NaN()), ErrInvalidMetricValue)
+	require.ErrorIs(t, SummaryMetric(context.Background(), "count", 1), ErrMetricKindConflict)
+}
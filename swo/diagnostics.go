@@ -0,0 +1,152 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swo
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/solarwinds/apm-go/internal/entryspans"
+	"github.com/solarwinds/apm-go/internal/log"
+	"github.com/solarwinds/apm-go/internal/metrics"
+	"github.com/solarwinds/apm-go/internal/oboe"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// diagnosticsState holds the components started by Start() which are reported
+// by DiagnosticsHandler. Access is guarded by diagnosticsMu.
+var (
+	diagnosticsMu       sync.RWMutex
+	diagnosticsUpdater  oboe.SettingsUpdater
+	diagnosticsResource *resource.Resource
+)
+
+// setDiagnosticsState stores the settings updater and resource reported by
+// DiagnosticsHandler. Pass nil values to clear them on shutdown.
+func setDiagnosticsState(su oboe.SettingsUpdater, res *resource.Resource) {
+	diagnosticsMu.Lock()
+	defer diagnosticsMu.Unlock()
+	diagnosticsUpdater = su
+	diagnosticsResource = res
+}
+
+func getDiagnosticsState() (oboe.SettingsUpdater, *resource.Resource) {
+	diagnosticsMu.RLock()
+	defer diagnosticsMu.RUnlock()
+	return diagnosticsUpdater, diagnosticsResource
+}
+
+type diagnostics struct {
+	// Running is true if the library has been started and not shut down
+	Running         bool                      `json:"running"`
+	Settings        *oboe.SettingsDiagnostics `json:"settings"`
+	SettingsUpdater *settingsUpdaterStatus    `json:"settingsUpdater"`
+	Resource        map[string]string         `json:"resource"`
+	EntrySpans      entrySpansDiagnostics     `json:"entrySpans"`
+	RateCounts      rateCounts                `json:"rateCounts"`
+}
+
+type settingsUpdaterStatus struct {
+	LastFetch   *time.Time `json:"lastFetch"`
+	LastSuccess *time.Time `json:"lastSuccess"`
+	LastError   string     `json:"lastError,omitempty"`
+}
+
+type rateCounts struct {
+	Requested    int64 `json:"requested"`
+	Sampled      int64 `json:"sampled"`
+	Limited      int64 `json:"limited"`
+	Traced       int64 `json:"traced"`
+	Through      int64 `json:"through"`
+	TriggerTrace int64 `json:"triggerTrace"`
+}
+
+type entrySpansDiagnostics struct {
+	Traces int `json:"traces"`
+	Spans  int `json:"spans"`
+}
+
+// DiagnosticsHandler returns an http.Handler which reports the runtime state of
+// the library as JSON: the current sampling settings and token buckets, the
+// status of the settings updater, the resource attributes, the number of active
+// entry spans and the current rate counters.
+//
+// The handler is opt-in and must be mounted by the application, preferably on
+// an internal-only listener, e.g.
+//
+//	mux.Handle("/debug/swo", swo.DiagnosticsHandler())
+func DiagnosticsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(collectDiagnostics()); err != nil {
+			log.Warningf("failed to write diagnostics: %s", err)
+		}
+	})
+}
+
+func collectDiagnostics() diagnostics {
+	var d diagnostics
+	if o := getGlobalOboe(); o != nil {
+		d.Running = true
+		d.Settings = oboe.GetSettingsDiagnostics(o)
+	}
+
+	su, res := getDiagnosticsState()
+	if su != nil {
+		status := su.Status()
+		d.SettingsUpdater = &settingsUpdaterStatus{}
+		if !status.LastFetch.IsZero() {
+			d.SettingsUpdater.LastFetch = &status.LastFetch
+		}
+		if !status.LastSuccess.IsZero() {
+			d.SettingsUpdater.LastSuccess = &status.LastSuccess
+		}
+		if status.LastError != nil {
+			d.SettingsUpdater.LastError = status.LastError.Error()
+		}
+	}
+
+	if res != nil {
+		d.Resource = make(map[string]string, res.Len())
+		for _, kv := range res.Attributes() {
+			d.Resource[string(kv.Key)] = kv.Value.Emit()
+		}
+	}
+
+	d.EntrySpans.Traces, d.EntrySpans.Spans = entryspans.Count()
+
+	// Read the counters without flushing them, they are reset by the metrics
+	// publisher on each export.
+	rc := metrics.RatesAggregator()
+	d.RateCounts = rateCounts{
+		Requested:    rc.Requested(),
+		Sampled:      rc.Sampled(),
+		Limited:      rc.Limited(),
+		Traced:       rc.Traced(),
+		Through:      rc.Through(),
+		TriggerTrace: rc.TriggerTrace(),
+	}
+	return d
+}
@@ -0,0 +1,98 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/solarwinds/apm-go/internal/oboe"
+	"github.com/solarwinds/apm-go/internal/oboetestutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+type fakeSettingsUpdater struct {
+	status oboe.SettingsUpdaterStatus
+}
+
+func (f fakeSettingsUpdater) Start(context.Context) func() { return func() {} }
+
+func (f fakeSettingsUpdater) Status() oboe.SettingsUpdaterStatus { return f.status }
+
+func getDiagnostics(t *testing.T) map[string]any {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	DiagnosticsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/swo", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return body
+}
+
+func TestDiagnosticsHandlerNotStarted(t *testing.T) {
+	withGlobalOboe(t, nil)
+	body := getDiagnostics(t)
+	assert.Equal(t, false, body["running"])
+	assert.Nil(t, body["settings"])
+	assert.Nil(t, body["settingsUpdater"])
+	assert.Contains(t, body, "entrySpans")
+	assert.Contains(t, body, "rateCounts")
+}
+
+func TestDiagnosticsHandler(t *testing.T) {
+	o := oboe.NewOboe()
+	o.UpdateSetting(oboetestutils.GetDefaultSettingForTest())
+	withGlobalOboe(t, o)
+	lastSuccess := time.Now().Add(-time.Minute)
+	setDiagnosticsState(fakeSettingsUpdater{oboe.SettingsUpdaterStatus{
+		LastFetch:   time.Now(),
+		LastSuccess: lastSuccess,
+		LastError:   errors.New("connection refused"),
+	}}, resource.NewSchemaless(semconv.ServiceName("my-service")))
+	t.Cleanup(func() { setDiagnosticsState(nil, nil) })
+
+	body := getDiagnostics(t)
+	assert.Equal(t, true, body["running"])
+
+	settings := body["settings"].(map[string]any)
+	assert.Equal(t, []any{"SAMPLE_START", "SAMPLE_THROUGH_ALWAYS", "TRIGGER_TRACE"}, settings["flags"])
+	assert.EqualValues(t, 1000000, settings["sampleRate"])
+	assert.Equal(t, "2m0s", settings["ttl"])
+	buckets := settings["buckets"].(map[string]any)
+	assert.Len(t, buckets, 3)
+	assert.EqualValues(t, 1000000, buckets["default"].(map[string]any)["capacity"])
+
+	su := body["settingsUpdater"].(map[string]any)
+	assert.Equal(t, "connection refused", su["lastError"])
+	assert.Equal(t, lastSuccess.Format(time.RFC3339Nano), su["lastSuccess"])
+
+	assert.Equal(t, map[string]any{"service.name": "my-service"}, body["resource"])
+}
+
+func TestDiagnosticsHandlerMethodNotAllowed(t *testing.T) {
+	rec := httptest.NewRecorder()
+	DiagnosticsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/swo", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "GET, HEAD", rec.Header().Get("Allow"))
+}
@@ -0,0 +1,205 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swo
+
+import (
+	"io"
+	"time"
+
+	"github.com/solarwinds/apm-go/internal/config"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingMode is either TracingEnabled or TracingDisabled
+type TracingMode string
+
+const (
+	// TracingEnabled means requests are sampled according to the sample rate
+	TracingEnabled TracingMode = "enabled"
+	// TracingDisabled means requests are neither traced nor continued
+	TracingDisabled TracingMode = "disabled"
+)
+
+// TransactionFilter sets the tracing mode of the requests whose URL matches
+// either RegEx or one of Extensions (e.g. "png"). Exactly one of RegEx and
+// Extensions must be set.
+type TransactionFilter struct {
+	RegEx      string
+	Extensions []string
+	Tracing    TracingMode
+}
+
+// SpanFilter drops the spans matching all of its set conditions before
+// export. Scope is the instrumentation scope name, where `*` matches any
+// characters, and Name is a regular expression matched against the span
+// name. The spans of one of Kinds and shorter than MinDuration, if set, match.
+// The entry spans, the spans with an error status and the spans with children
+// are never dropped.
+type SpanFilter struct {
+	Scope       string
+	Name        string
+	Kinds       []trace.SpanKind
+	MinDuration time.Duration
+}
+
+// Option configures the agent started by StartWithOptions. Options take
+// precedence over the config file and the environment variables.
+type Option func(o *options)
+
+type options struct {
+	resourceAttrs    []attribute.KeyValue
+	configOpts       []config.Option
+	spanProcessors   []sdktrace.SpanProcessor
+	spanExporter     sdktrace.SpanExporter
+	logWriter        io.Writer
+	errorHandler     bool
+	samplingStrategy SamplingStrategy
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithResourceAttributes adds the given attributes to the otel
+// `resource.Resource` that is supplied to the otel `TracerProvider`
+func WithResourceAttributes(attrs ...attribute.KeyValue) Option {
+	return func(o *options) {
+		o.resourceAttrs = append(o.resourceAttrs, attrs...)
+	}
+}
+
+// WithServiceKey sets the service key, in the form of `<api token>:<service name>`
+func WithServiceKey(key string) Option {
+	return withConfig(config.WithServiceKey(key))
+}
+
+// WithCollector sets the host and port of the SolarWinds Observability collector
+func WithCollector(collector string) Option {
+	return withConfig(config.WithCollector(collector))
+}
+
+// WithSampleRate sets the local sample rate, between 0 and 1000000
+func WithSampleRate(rate int) Option {
+	return withConfig(config.WithSampleRate(rate))
+}
+
+// WithTracingMode sets the local tracing mode
+func WithTracingMode(mode TracingMode) Option {
+	return withConfig(config.WithTracingMode(config.TracingMode(mode)))
+}
+
+// WithProxy sets the HTTP/HTTPS proxy url in the format of
+// "scheme://<username>:<password>@<host>:<port>"
+func WithProxy(proxy string) Option {
+	return withConfig(config.WithProxy(proxy))
+}
+
+// WithTransactionFilters sets the transaction filters, replacing those defined
+// in the config file. Invalid filters are logged and ignored.
+func WithTransactionFilters(filters ...TransactionFilter) Option {
+	converted := make([]config.TransactionFilter, 0, len(filters))
+	for _, f := range filters {
+		converted = append(converted, config.TransactionFilter{
+			Type:       config.URL,
+			RegEx:      f.RegEx,
+			Extensions: f.Extensions,
+			Tracing:    config.TracingMode(f.Tracing),
+		})
+	}
+	return withConfig(config.WithTransactionFilters(converted))
+}
+
+// WithRedactedKeys redacts the values of the span and event attributes whose
+// key matches one of keys, either exactly or as a glob pattern, e.g.
+// `*.password`. The keys are added to those defined in the config file.
+func WithRedactedKeys(keys ...string) Option {
+	return withConfig(config.WithRedaction(config.RedactionConfig{Keys: keys}))
+}
+
+// WithRedactedValues redacts the parts of the span and event attribute values
+// matching one of the regular expressions, e.g. email addresses. The patterns
+// are added to those defined in the config file.
+func WithRedactedValues(patterns ...string) Option {
+	return withConfig(config.WithRedaction(config.RedactionConfig{Values: patterns}))
+}
+
+// WithSpanFilters sets the span filters, replacing those defined in the
+// config file. Invalid filters are logged and ignored.
+func WithSpanFilters(filters ...SpanFilter) Option {
+	converted := make([]config.SpanFilter, 0, len(filters))
+	for _, f := range filters {
+		sf := config.SpanFilter{Scope: f.Scope, Name: f.Name}
+		for _, k := range f.Kinds {
+			sf.Kinds = append(sf.Kinds, k.String())
+		}
+		if f.MinDuration != 0 {
+			sf.MinDuration = f.MinDuration.String()
+		}
+		converted = append(converted, sf)
+	}
+	return withConfig(config.WithSpanFilters(converted))
+}
+
+// WithSpanProcessors registers additional span processors with the
+// `TracerProvider`
+func WithSpanProcessors(procs ...sdktrace.SpanProcessor) Option {
+	return func(o *options) {
+		o.spanProcessors = append(o.spanProcessors, procs...)
+	}
+}
+
+// WithSpanExporter replaces the default OTLP span exporter
+func WithSpanExporter(exporter sdktrace.SpanExporter) Option {
+	return func(o *options) {
+		o.spanExporter = exporter
+	}
+}
+
+// WithLogWriter sets the output destination for the internal logger
+func WithLogWriter(w io.Writer) Option {
+	return func(o *options) {
+		o.logWriter = w
+	}
+}
+
+// WithErrorHandler sets the global OpenTelemetry error handler to one which
+// logs the errors of the SDK, e.g. the failures to export the spans and
+// metrics, rate limited by the `exporter` component. It replaces the handler
+// set by the application, if any, so it isn't set by default.
+func WithErrorHandler() Option {
+	return func(o *options) {
+		o.errorHandler = true
+	}
+}
+
+// WithSamplingStrategy replaces the default sampling strategy, which decides
+// which requests are traced
+func WithSamplingStrategy(strategy SamplingStrategy) Option {
+	return func(o *options) {
+		o.samplingStrategy = strategy
+	}
+}
+
+func withConfig(opt config.Option) Option {
+	return func(o *options) {
+		o.configOpts = append(o.configOpts, opt)
+	}
+}
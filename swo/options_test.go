@@ -0,0 +1,197 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/solarwinds/apm-go/internal/config"
+	"github.com/solarwinds/apm-go/internal/oboe"
+	"github.com/solarwinds/apm-go/internal/oboetestutils"
+	"github.com/solarwinds/apm-go/internal/utils"
+	"github.com/solarwinds/apm-go/internal/w3cfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewOptions(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	proc := sdktrace.NewSimpleSpanProcessor(exporter)
+	var buf utils.SafeBuffer
+	o := newOptions(
+		WithResourceAttributes(attribute.String("foo", "bar")),
+		WithResourceAttributes(attribute.String("baz", "qux")),
+		WithServiceKey("token:name"),
+		WithSpanExporter(exporter),
+		WithSpanProcessors(proc),
+		WithLogWriter(&buf),
+		WithErrorHandler(),
+		WithSamplingStrategy(neverStrategy{}),
+	)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("foo", "bar"),
+		attribute.String("baz", "qux"),
+	}, o.resourceAttrs)
+	assert.Len(t, o.configOpts, 1)
+	assert.Equal(t, exporter, o.spanExporter)
+	assert.Equal(t, []sdktrace.SpanProcessor{proc}, o.spanProcessors)
+	assert.Equal(t, &buf, o.logWriter)
+	assert.True(t, o.errorHandler)
+	assert.False(t, newOptions().errorHandler)
+	assert.Equal(t, neverStrategy{}, o.samplingStrategy)
+}
+
+// neverStrategy doesn't trace any request, using only the exported API
+type neverStrategy struct{}
+
+func (neverStrategy) Sample(s SamplingSettings, req SamplingRequest) SampleDecision {
+	bucket := s.Bucket(req.TriggerTrace)
+	return NewSampleDecision(bucket.Count(false, req.Continued, false, false), s, bucket, oboe.TtNotRequested)
+}
+
+func TestSamplingStrategy(t *testing.T) {
+	o := oboe.NewOboeWithStrategy(newOptions(WithSamplingStrategy(neverStrategy{})).samplingStrategy)
+	o.UpdateSetting(oboetestutils.GetDefaultSettingForTest())
+	dec := o.SampleRequest(false, "", "", oboe.ModeTriggerTraceNotPresent, w3cfmt.SwTraceState{})
+	assert.False(t, dec.Trace())
+	assert.True(t, dec.Enabled())
+	assert.Equal(t, 1000000, dec.SampleRate())
+	assert.Equal(t, float64(1000000), dec.BucketCapacity())
+}
+
+func TestStartWithOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"flags":"SAMPLE_START,SAMPLE_THROUGH_ALWAYS","value":1000000,"ttl":60,"arguments":{"BucketCapacity":100,"BucketRate":100}}`))
+	}))
+	defer server.Close()
+
+	const key = "ae38315f6116585d64d82ec2455aa3ec61e02fee25d286f74ace9e4fea189217:option-service-name"
+	t.Cleanup(func() {
+		config.Load()
+		oboe.ReloadURLsConfig(config.GetTransactionFiltering())
+		SetLogOutput(os.Stderr)
+	})
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4317")
+	t.Setenv("SW_APM_DISABLED_RESOURCE_DETECTORS", "ec2,azurevm,uams")
+
+	exporter := tracetest.NewInMemoryExporter()
+	extra := tracetest.NewInMemoryExporter()
+	var buf utils.SafeBuffer
+	shutdown, err := StartWithOptions(
+		WithServiceKey(key),
+		WithSampleRate(config.MaxSampleRate),
+		WithTracingMode(TracingEnabled),
+		WithTransactionFilters(TransactionFilter{RegEx: `/healthz$`, Tracing: TracingDisabled}),
+		WithSpanExporter(exporter),
+		WithSpanProcessors(sdktrace.NewSimpleSpanProcessor(extra)),
+		WithLogWriter(&buf),
+		withConfig(func(c *config.Config) { c.SettingsURL = server.URL }),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, key, config.GetServiceKey())
+	assert.True(t, config.SamplingConfigured())
+	assert.Equal(t, config.EnabledTracingMode, config.GetTracingMode())
+	require.Len(t, config.GetTransactionFiltering(), 1)
+	require.True(t, WaitForReady(context.Background()))
+
+	tracer := otel.Tracer("options-test")
+	_, span := tracer.Start(context.Background(), "health", trace.WithAttributes(attribute.String("url.path", "/healthz")))
+	span.End()
+	for range 10 {
+		_, span = tracer.Start(context.Background(), "api", trace.WithAttributes(attribute.String("url.path", "/api")))
+		span.End()
+	}
+	tp, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider)
+	require.True(t, ok)
+	require.NoError(t, tp.ForceFlush(context.Background()))
+
+	for _, exp := range []*tracetest.InMemoryExporter{exporter, extra} {
+		spans := exp.GetSpans()
+		require.Len(t, spans, 10)
+		for _, s := range spans {
+			assert.Equal(t, "api", s.Name)
+		}
+	}
+	shutdown()
+}
+
+func TestRedactionOptions(t *testing.T) {
+	t.Cleanup(func() { config.Load() })
+	o := newOptions(
+		WithRedactedKeys("*.password"),
+		WithRedactedValues(`\d{4}-\d{4}-\d{4}-\d{4}`),
+	)
+	config.Load(append(o.configOpts, func(c *config.Config) { c.ReportQueryString = false })...)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(wrapExportProcessor(sdktrace.NewSimpleSpanProcessor(exporter), noop.NewMeterProvider())),
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+	)
+	_, span := tp.Tracer("redaction-test").Start(context.Background(), "checkout", trace.WithAttributes(
+		attribute.String("user.password", "hunter2"),
+		attribute.String("url.full", "https://example.com/pay?card=1234-5678-9012-3456"),
+		attribute.String("note", "card 1234-5678-9012-3456"),
+	))
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("user.password", "[REDACTED]"),
+		attribute.String("url.full", "https://example.com/pay"),
+		attribute.String("note", "card [REDACTED]"),
+	}, spans[0].Attributes)
+}
+
+func TestSpanFilterOptions(t *testing.T) {
+	t.Cleanup(func() { config.Load() })
+	o := newOptions(WithSpanFilters(
+		SpanFilter{Scope: "github.com/redis/*", Kinds: []trace.SpanKind{trace.SpanKindClient}, MinDuration: time.Hour},
+		SpanFilter{Name: "("},
+	))
+	config.Load(o.configOpts...)
+	require.Equal(t, []config.SpanFilter{
+		{Scope: "github.com/redis/*", Kinds: []string{"client"}, MinDuration: "1h0m0s"},
+	}, config.GetSpanFilters())
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(wrapExportProcessor(sdktrace.NewSimpleSpanProcessor(exporter), noop.NewMeterProvider())),
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+	)
+	ctx, entry := tp.Tracer("app").Start(context.Background(), "GET /")
+	_, span := tp.Tracer("github.com/redis/go-redis/extra/redisotel").Start(ctx, "get", trace.WithSpanKind(trace.SpanKindClient))
+	span.End()
+	entry.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "GET /", spans[0].Name)
+}
@@ -0,0 +1,50 @@
+// © 2025 SolarWinds Worldwide, LLC. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package swo
+
+import "github.com/solarwinds/apm-go/internal/oboe"
+
+// SamplingStrategy makes the sampling decisions of the requests starting or
+// continuing a trace, see WithSamplingStrategy. The decision should be made
+// with NewSampleDecision, after reporting the request to a token bucket of the
+// settings with TokenBucket.Count so that the sampling metrics stay accurate.
+type SamplingStrategy = oboe.SamplingStrategy
+
+// SamplingRequest holds the inputs of a sampling decision
+type SamplingRequest = oboe.SamplingRequest
+
+// SamplingSettings is the read-only view of the sampling settings of a
+// request, i.e. the settings of the service merged with those of the
+// transaction and the URL of the request
+type SamplingSettings = oboe.Settings
+
+// TokenBucket rate limits the sampled requests
+type TokenBucket = oboe.TokenBucket
+
+// SampleDecision is the sampling decision of a request
+type SampleDecision = oboe.SampleDecision
+
+// TriggerTraceMode is the trigger trace mode of a request
+type TriggerTraceMode = oboe.TriggerTraceMode
+
+// SampleSource is where the sample rate of a decision comes from
+type SampleSource = oboe.SampleSource
+
+var (
+	// NewSampleDecision returns the decision of a SamplingStrategy
+	NewSampleDecision = oboe.NewSampleDecision
+	// DefaultSamplingStrategy returns the strategy used by default, driven by
+	// the setting flags, the sample rate and the token buckets
+	DefaultSamplingStrategy = oboe.NewDefaultSamplingStrategy
+)